@@ -0,0 +1,292 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+var (
+	// ErrDuplicate callback with the same order, payment, status and signature was already processed
+	ErrDuplicate = errors.New("callback already processed")
+	// ErrStatusRegression callback would move order back to an earlier status
+	ErrStatusRegression = errors.New("callback status is older than processed one")
+)
+
+// Store keeps processed callbacks and last known order statuses
+type Store interface {
+	// Claim marks key as being processed, returns false when key is already claimed
+	Claim(ctx context.Context, key string) (bool, error)
+	// Release removes claim, so redelivered callback is processed again
+	Release(ctx context.Context, key string) error
+	// Status returns last accepted status of order, empty if unknown
+	Status(ctx context.Context, orderKey string) (string, error)
+	// AdvanceStatus atomically stores status unless order already has status of higher rank,
+	// returns status it replaced and false when status would move order backwards
+	AdvanceStatus(ctx context.Context, orderKey string, status string, rank int) (previous string, advanced bool, err error)
+	// RevertStatus puts previous status back (removes status when previous is empty) if order still has status,
+	// so status of callback that failed to dispatch does not block its redelivery
+	RevertStatus(ctx context.Context, orderKey string, status string, previous string, previousRank int) error
+}
+
+// Dedup dispatcher that skips already processed callbacks and callbacks moving order backwards.
+// Skipped callbacks are acknowledged, so Fondy stops redelivering them.
+type Dedup struct {
+	store Store
+	next  Dispatcher
+
+	// OnSkip is called with ErrDuplicate or ErrStatusRegression for every skipped callback
+	OnSkip func(event *Event, reason error)
+}
+
+func NewDedup(store Store, next Dispatcher) *Dedup {
+	return &Dedup{
+		store: store,
+		next:  next,
+		OnSkip: func(event *Event, reason error) {
+			log.Printf("Fondy callback: skip %s of order %s: %v", event.Status, event.OrderID, reason)
+		},
+	}
+}
+
+func (d *Dedup) Dispatch(ctx context.Context, event *Event) error {
+	key := DedupKey(event)
+
+	claimed, err := d.store.Claim(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		d.skip(event, ErrDuplicate)
+		return nil
+	}
+
+	// status is advanced before dispatch in one atomic step, so concurrent deliveries (also from other
+	// instances sharing the store) cannot both pass the check
+	previous, advanced, err := d.store.AdvanceStatus(ctx, statusKey(event), event.Status, statusRank(event.Status))
+	if err != nil {
+		d.release(ctx, key)
+		return err
+	}
+
+	if !advanced {
+		d.skip(event, ErrStatusRegression)
+		return nil
+	}
+
+	err = d.next.Dispatch(ctx, event)
+	if err != nil {
+		// status is rolled back, so callbacks processed before redelivery are checked against what was really handled
+		d.revert(ctx, event, previous)
+		d.release(ctx, key)
+		return err
+	}
+
+	return nil
+}
+
+func (d *Dedup) skip(event *Event, reason error) {
+	if d.OnSkip != nil {
+		d.OnSkip(event, reason)
+	}
+}
+
+func (d *Dedup) release(ctx context.Context, key string) {
+	err := d.store.Release(ctx, key)
+	if err != nil {
+		log.Printf("Fondy callback: cannot release %s: %v", key, err)
+	}
+}
+
+func (d *Dedup) revert(ctx context.Context, event *Event, previous string) {
+	err := d.store.RevertStatus(ctx, statusKey(event), event.Status, previous, statusRank(previous))
+	if err != nil {
+		log.Printf("Fondy callback: cannot revert status of %s: %v", event.OrderID, err)
+	}
+}
+
+// DedupKey identifies single callback delivery by order ID, payment ID, status and signature
+func DedupKey(event *Event) string {
+	return strings.Join([]string{statusKey(event), event.PaymentID, event.Status, event.Signature}, "|")
+}
+
+// statusKey separates payment orders from settlements made for the same order ID
+func statusKey(event *Event) string {
+	kind := strings.SplitN(string(event.Type), ".", 2)[0]
+
+	return kind + ":" + event.OrderID
+}
+
+// statusRank orders statuses by lifecycle, final statuses share rank, reversal comes last
+func statusRank(status string) int {
+	switch consts.Status(status) {
+	case "":
+		return -1
+	case consts.StatusCreated:
+		return 0
+	case consts.StatusProcessing:
+		return 1
+	case consts.StatusReversed:
+		return 3
+	default:
+		return 2
+	}
+}
+
+type memoryStatus struct {
+	status string
+	rank   int
+	at     time.Time
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	claims   map[string]time.Time
+	statuses map[string]memoryStatus
+	sweptAt  time.Time
+}
+
+// NewMemoryStore creates in-process store, claims and statuses expire after ttl (zero means never)
+func NewMemoryStore(ttl time.Duration) Store {
+	return &memoryStore{
+		ttl:      ttl,
+		claims:   make(map[string]time.Time),
+		statuses: make(map[string]memoryStatus),
+		sweptAt:  time.Now(),
+	}
+}
+
+func (s *memoryStore) Claim(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	if claimedAt, ok := s.claims[key]; ok && !s.expired(claimedAt, now) {
+		return false, nil
+	}
+
+	s.claims[key] = now
+
+	return true, nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, key)
+
+	return nil
+}
+
+func (s *memoryStore) Status(_ context.Context, orderKey string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current(orderKey, time.Now()).status, nil
+}
+
+func (s *memoryStore) AdvanceStatus(_ context.Context, orderKey string, status string, rank int) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	last, ok := s.statuses[orderKey]
+	if ok && s.expired(last.at, now) {
+		last, ok = memoryStatus{}, false
+	}
+
+	if ok && rank < last.rank {
+		return last.status, false, nil
+	}
+
+	s.statuses[orderKey] = memoryStatus{status: status, rank: rank, at: now}
+
+	return last.status, true, nil
+}
+
+func (s *memoryStore) RevertStatus(_ context.Context, orderKey string, status string, previous string, previousRank int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.current(orderKey, now).status != status {
+		return nil
+	}
+
+	if previous == "" {
+		delete(s.statuses, orderKey)
+		return nil
+	}
+
+	s.statuses[orderKey] = memoryStatus{status: previous, rank: previousRank, at: now}
+
+	return nil
+}
+
+func (s *memoryStore) current(orderKey string, now time.Time) memoryStatus {
+	status, ok := s.statuses[orderKey]
+	if !ok || s.expired(status.at, now) {
+		return memoryStatus{}
+	}
+
+	return status
+}
+
+func (s *memoryStore) expired(at time.Time, now time.Time) bool {
+	return s.ttl > 0 && now.Sub(at) >= s.ttl
+}
+
+// sweep deletes expired claims and statuses, at most once per ttl so cost is spread over calls
+func (s *memoryStore) sweep(now time.Time) {
+	if s.ttl <= 0 || now.Sub(s.sweptAt) < s.ttl {
+		return
+	}
+
+	s.sweptAt = now
+
+	for key, claimedAt := range s.claims {
+		if s.expired(claimedAt, now) {
+			delete(s.claims, key)
+		}
+	}
+
+	for key, status := range s.statuses {
+		if s.expired(status.at, now) {
+			delete(s.statuses, key)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func dedupEvent(eventType EventType, status string) *Event {
+	return &Event{Type: eventType, OrderID: "order-1", PaymentID: "1", Status: status, Signature: status}
+}
+
+func TestDedupRevertsStatusWhenDispatchFails(t *testing.T) {
+	store := NewMemoryStore(0)
+	ctx := context.Background()
+	fail := true
+
+	dedup := NewDedup(store, DispatcherFunc(func(_ context.Context, event *Event) error {
+		if fail && event.Status == "approved" {
+			return errors.New("dispatcher is down")
+		}
+
+		return nil
+	}))
+
+	var skipped []error
+	dedup.OnSkip = func(_ *Event, reason error) { skipped = append(skipped, reason) }
+
+	err := dedup.Dispatch(ctx, dedupEvent(EventOrderCreated, "created"))
+	if err != nil {
+		t.Fatalf("created: %v", err)
+	}
+
+	err = dedup.Dispatch(ctx, dedupEvent(EventOrderApproved, "approved"))
+	if err == nil {
+		t.Fatalf("failed dispatch not reported")
+	}
+
+	status, _ := store.Status(ctx, "order:order-1")
+	if status != "created" {
+		t.Errorf("status after failed dispatch = %q, want created", status)
+	}
+
+	err = dedup.Dispatch(ctx, dedupEvent(EventOrderProcessing, "processing"))
+	if err != nil {
+		t.Fatalf("processing: %v", err)
+	}
+
+	fail = false
+
+	err = dedup.Dispatch(ctx, dedupEvent(EventOrderApproved, "approved"))
+	if err != nil {
+		t.Fatalf("redelivered approved: %v", err)
+	}
+
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v, want none", skipped)
+	}
+
+	status, _ = store.Status(ctx, "order:order-1")
+	if status != "approved" {
+		t.Errorf("status = %q, want approved", status)
+	}
+}
+
+func TestDedupSkipsDuplicatesAndRegressions(t *testing.T) {
+	ctx := context.Background()
+	dedup := NewDedup(NewMemoryStore(0), DispatcherFunc(func(context.Context, *Event) error { return nil }))
+
+	var skipped []error
+	dedup.OnSkip = func(_ *Event, reason error) { skipped = append(skipped, reason) }
+
+	for _, event := range []*Event{
+		dedupEvent(EventOrderApproved, "approved"),
+		dedupEvent(EventOrderApproved, "approved"),
+		dedupEvent(EventOrderProcessing, "processing"),
+	} {
+		err := dedup.Dispatch(ctx, event)
+		if err != nil {
+			t.Fatalf("dispatch %s: %v", event.Status, err)
+		}
+	}
+
+	if len(skipped) != 2 || !errors.Is(skipped[0], ErrDuplicate) || !errors.Is(skipped[1], ErrStatusRegression) {
+		t.Errorf("skipped = %v, want duplicate and regression", skipped)
+	}
+}
+
+func TestMemoryStoreEvictsExpiredEntries(t *testing.T) {
+	store := NewMemoryStore(10 * time.Millisecond).(*memoryStore)
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := store.Claim(ctx, key)
+		if err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+
+		_, _, err = store.AdvanceStatus(ctx, key, "approved", 2)
+		if err != nil {
+			t.Fatalf("advance: %v", err)
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	claimed, err := store.Claim(ctx, "a")
+	if err != nil || !claimed {
+		t.Fatalf("expired claim not reclaimed: %v, %v", claimed, err)
+	}
+
+	if len(store.claims) != 1 || len(store.statuses) != 0 {
+		t.Errorf("claims = %d, statuses = %d after sweep, want 1 and 0", len(store.claims), len(store.statuses))
+	}
+}
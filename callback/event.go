@@ -0,0 +1,105 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"context"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+	"github.com/stremovskyy/gofondy/models/models_v2"
+)
+
+type EventType string
+
+const (
+	EventOrderCreated    EventType = "order.created"
+	EventOrderProcessing EventType = "order.processing"
+	EventOrderApproved   EventType = "order.approved"
+	EventOrderDeclined   EventType = "order.declined"
+	EventOrderExpired    EventType = "order.expired"
+	EventOrderReversed   EventType = "order.reversed"
+	EventOrderUnknown    EventType = "order.unknown"
+
+	EventSettlement EventType = "settlement"
+	EventReverse    EventType = "settlement.reverse"
+)
+
+func orderEventType(status *consts.Status) EventType {
+	if status == nil {
+		return EventOrderUnknown
+	}
+
+	switch *status {
+	case consts.StatusCreated:
+		return EventOrderCreated
+	case consts.StatusProcessing:
+		return EventOrderProcessing
+	case consts.StatusApproved:
+		return EventOrderApproved
+	case consts.StatusDeclined:
+		return EventOrderDeclined
+	case consts.StatusExpired:
+		return EventOrderExpired
+	case consts.StatusReversed:
+		return EventOrderReversed
+	default:
+		return EventOrderUnknown
+	}
+}
+
+// Event verified callback from Fondy
+type Event struct {
+	Type      EventType
+	Version   string
+	OrderID   string
+	PaymentID string
+	Status    string
+	Signature string
+	Merchant  *models.MerchantAccount
+	Order     *models.Order
+	// Settlement protocol 2.0 split or split reverse order with its transactions
+	Settlement *models_v2.Order
+	// Report payouts of Settlement per receiver
+	Report *models_v2.SettlementReport
+	// Params raw callback parameters as they were signed
+	Params map[string]string
+	Raw    []byte
+}
+
+// Dispatcher receives verified callback events. Returning error makes Fondy deliver the callback again.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, event *Event) error
+}
+
+// DispatcherFunc adapts function to Dispatcher
+type DispatcherFunc func(ctx context.Context, event *Event) error
+
+func (f DispatcherFunc) Dispatch(ctx context.Context, event *Event) error {
+	return f(ctx, event)
+}
+
+// MerchantResolver returns merchant account callback was sent for, nil account means merchant is unknown
+type MerchantResolver func(merchantID string) (*models.MerchantAccount, error)
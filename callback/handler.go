@@ -0,0 +1,249 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+const defaultMaxBodySize = 1 << 20
+
+// Handler receives protocol 1.0 server callbacks, both JSON and form encoded.
+//
+// Fondy treats only 200 as acknowledgement and redelivers callback otherwise, so
+// handler answers 200 when event is dispatched, 500 when dispatcher fails (to get it again)
+// and 4xx for requests that will never be valid.
+type Handler struct {
+	receiver
+}
+
+func NewHandler(resolver MerchantResolver, dispatcher Dispatcher) *Handler {
+	return &Handler{receiver: newReceiver(resolver, dispatcher)}
+}
+
+// receiver common part of protocol 1.0 and 2.0 handlers
+type receiver struct {
+	resolver    MerchantResolver
+	dispatcher  Dispatcher
+	logger      *log.Logger
+	MaxBodySize int64
+}
+
+func newReceiver(resolver MerchantResolver, dispatcher Dispatcher) receiver {
+	return receiver{
+		resolver:    resolver,
+		dispatcher:  dispatcher,
+		logger:      log.New(log.Writer(), "Fondy callback: ", log.LstdFlags),
+		MaxBodySize: defaultMaxBodySize,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, ok := h.read(w, r)
+	if !ok {
+		return
+	}
+
+	params, err := parseParams(r.Header.Get("Content-Type"), raw)
+	if err != nil {
+		h.logger.Printf("[ERROR] cannot parse callback: %v", err)
+		http.Error(w, "cannot parse callback", http.StatusBadRequest)
+		return
+	}
+
+	merchant, status, err := h.resolve(params["merchant_id"])
+	if err != nil {
+		h.logger.Printf("[ERROR] %v", err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	valid := models.ParamsSignatureValid(merchant.MerchantKey, params)
+	if !valid && merchant.MerchantCreditKey != "" {
+		valid = models.ParamsSignatureValid(merchant.MerchantCreditKey, params)
+	}
+
+	if !valid {
+		h.logger.Printf("[ERROR] invalid signature for order %s", params["order_id"])
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	order, err := paramsToOrder(params)
+	if err != nil {
+		h.logger.Printf("[ERROR] cannot decode order %s: %v", params["order_id"], err)
+		http.Error(w, "cannot decode order", http.StatusBadRequest)
+		return
+	}
+
+	event := &Event{
+		Type:      orderEventType(order.OrderStatus),
+		Version:   "1.0",
+		OrderID:   params["order_id"],
+		PaymentID: params["payment_id"],
+		Status:    params["order_status"],
+		Signature: params["signature"],
+		Merchant:  merchant,
+		Order:     order,
+		Params:    params,
+		Raw:       raw,
+	}
+
+	h.dispatch(w, r, event)
+}
+
+func (h *receiver) read(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(r.Body, h.MaxBodySize))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return raw, true
+}
+
+func (h *receiver) resolve(merchantID string) (*models.MerchantAccount, int, error) {
+	if merchantID == "" {
+		return nil, http.StatusBadRequest, errors.New("callback has no merchant_id")
+	}
+
+	merchant, err := h.resolver(merchantID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("cannot resolve merchant %s: %w", merchantID, err)
+	}
+
+	if merchant == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("unknown merchant %s", merchantID)
+	}
+
+	return merchant, http.StatusOK, nil
+}
+
+func (h *receiver) dispatch(w http.ResponseWriter, r *http.Request, event *Event) {
+	err := h.dispatcher.Dispatch(r.Context(), event)
+	if err != nil {
+		h.logger.Printf("[ERROR] dispatch of %s failed: %v", event.Type, err)
+		http.Error(w, "callback processing failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseParams returns callback parameters as strings, exactly as Fondy signed them
+func parseParams(contentType string, raw []byte) (map[string]string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType == "application/x-www-form-urlencoded" || (mediaType != "application/json" && !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{"))) {
+		values, err := url.ParseQuery(string(raw))
+		if err != nil {
+			return nil, err
+		}
+
+		params := make(map[string]string, len(values))
+		for name := range values {
+			params[name] = values.Get(name)
+		}
+
+		return params, nil
+	}
+
+	return models.JSONParams(raw)
+}
+
+// paramsToOrder converts string parameters into typed order, numbers are restored for numeric fields
+func paramsToOrder(params map[string]string) (*models.Order, error) {
+	fields := make(map[string]interface{}, len(params))
+	orderType := reflect.TypeOf(models.Order{})
+
+	kinds := make(map[string]reflect.Type, orderType.NumField())
+	for i := 0; i < orderType.NumField(); i++ {
+		name := strings.Split(orderType.Field(i).Tag.Get("json"), ",")[0]
+		kinds[name] = orderType.Field(i).Type
+	}
+
+	for name, value := range params {
+		fieldType, ok := kinds[name]
+		if !ok || value == "" {
+			continue
+		}
+
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int64, reflect.Float64, reflect.Interface:
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				fields[name] = json.Number(value)
+				continue
+			}
+
+			if fieldType.Kind() != reflect.Interface {
+				return nil, fmt.Errorf("field %s is not a number: %s", name, value)
+			}
+		}
+
+		fields[name] = value
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var order models.Order
+	err = json.Unmarshal(b, &order)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.AdditionalInfoString != nil && *order.AdditionalInfoString != "" {
+		ai, err := models.UnmarshalAdditionalInfo([]byte(*order.AdditionalInfoString))
+		if err == nil {
+			order.AdditionalInfo = &ai
+		}
+	}
+
+	return &order, nil
+}
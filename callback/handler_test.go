@@ -0,0 +1,128 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+func callbackRequest(key string) *http.Request {
+	params := map[string]string{
+		"merchant_id":     "1396424",
+		"order_id":        "0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11",
+		"order_status":    "approved",
+		"response_status": "success",
+		"amount":          "1000",
+		"currency":        "UAH",
+	}
+	params["signature"] = models.ParamsSignature(key, params)
+
+	form := url.Values{}
+	for name, value := range params {
+		form.Set(name, value)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+func TestHandlerSignature(t *testing.T) {
+	merchant := models.NewMerchantAccount("1396424", "test", "")
+	resolver := func(string) (*models.MerchantAccount, error) { return merchant, nil }
+
+	tests := []struct {
+		name string
+		key  string
+		code int
+	}{
+		{name: "merchant key", key: "test", code: http.StatusOK},
+		{name: "empty credit key", key: "", code: http.StatusForbidden},
+		{name: "other key", key: "other", code: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatched := false
+			handler := NewHandler(resolver, DispatcherFunc(func(context.Context, *Event) error {
+				dispatched = true
+				return nil
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, callbackRequest(tt.key))
+
+			if w.Code != tt.code {
+				t.Errorf("status = %d, want %d", w.Code, tt.code)
+			}
+
+			if dispatched != (tt.code == http.StatusOK) {
+				t.Errorf("dispatched = %v", dispatched)
+			}
+		})
+	}
+}
+
+func TestV2HandlerSignature(t *testing.T) {
+	merchant := models.NewMerchantAccount("1396424", "test", "")
+	resolver := func(string) (*models.MerchantAccount, error) { return merchant, nil }
+	data := base64.StdEncoding.EncodeToString([]byte(`{"order":{"order_id":"split-1","merchant_id":1396424,"amount":"1000","order_status":"approved","response_status":"success"}}`))
+
+	tests := []struct {
+		name string
+		key  string
+		code int
+	}{
+		{name: "merchant key", key: "test", code: http.StatusOK},
+		{name: "empty credit key", key: "", code: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := fmt.Sprintf("%x", sha1.Sum([]byte(tt.key+"|"+data)))
+			body := `{"response":{"version":"2.0","data":"` + data + `","signature":"` + signature + `"}}`
+
+			handler := NewV2Handler(resolver, DispatcherFunc(func(context.Context, *Event) error { return nil }))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body)))
+
+			if w.Code != tt.code {
+				t.Errorf("status = %d, want %d", w.Code, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/stremovskyy/gofondy/models/models_v2"
+)
+
+// V2Handler receives protocol 2.0 server callbacks of split and split reverse orders.
+// Acknowledgement rules are the same as for Handler.
+type V2Handler struct {
+	receiver
+}
+
+func NewV2Handler(resolver MerchantResolver, dispatcher Dispatcher) *V2Handler {
+	return &V2Handler{receiver: newReceiver(resolver, dispatcher)}
+}
+
+func (h *V2Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, ok := h.read(w, r)
+	if !ok {
+		return
+	}
+
+	wrapper, err := unmarshalEnvelope(raw)
+	if err != nil {
+		h.logger.Printf("[ERROR] cannot parse callback: %v", err)
+		http.Error(w, "cannot parse callback", http.StatusBadRequest)
+		return
+	}
+
+	order, err := wrapper.Order()
+	if err != nil {
+		h.logger.Printf("[ERROR] cannot decode order: %v", err)
+		http.Error(w, "cannot decode order", http.StatusBadRequest)
+		return
+	}
+
+	var merchantID string
+	if order.MerchantID != 0 {
+		merchantID = strconv.FormatInt(order.MerchantID, 10)
+	}
+
+	merchant, status, err := h.resolve(merchantID)
+	if err != nil {
+		h.logger.Printf("[ERROR] %v", err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	valid := wrapper.SignIsValid(merchant.MerchantKey)
+	if !valid && merchant.MerchantCreditKey != "" {
+		valid = wrapper.SignIsValid(merchant.MerchantCreditKey)
+	}
+
+	if !valid {
+		h.logger.Printf("[ERROR] invalid signature for order %s", stringValue(order.OrderID))
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	var paymentID string
+	if order.PaymentID != nil {
+		paymentID = strconv.FormatInt(*order.PaymentID, 10)
+	}
+
+	event := &Event{
+		Type:       settlementEventType(order),
+		Version:    wrapper.Response.Version,
+		OrderID:    stringValue(order.OrderID),
+		PaymentID:  paymentID,
+		Status:     settlementStatus(order),
+		Signature:  wrapper.Response.Signature,
+		Merchant:   merchant,
+		Settlement: order,
+		Report:     models_v2.NewSettlementReport(order),
+		Raw:        raw,
+	}
+
+	h.dispatch(w, r, event)
+}
+
+// unmarshalEnvelope accepts both {"response": {...}} and bare {"version", "data", "signature"} bodies
+func unmarshalEnvelope(raw []byte) (*models_v2.ResponseWrapper, error) {
+	wrapper, err := models_v2.UnmarshalResponse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapper.Response.Data) == 0 {
+		err = json.Unmarshal(raw, &wrapper.Response)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(wrapper.Response.Data) == 0 || wrapper.Response.Signature == "" {
+		return nil, errors.New("callback has no data or signature")
+	}
+
+	return &wrapper, nil
+}
+
+func settlementEventType(order *models_v2.Order) EventType {
+	if order.ReverseID != nil || order.ReverseStatus != "" || (order.OrderType != nil && *order.OrderType == "reverse") {
+		return EventReverse
+	}
+
+	return EventSettlement
+}
+
+func settlementStatus(order *models_v2.Order) string {
+	if order.ReverseStatus != "" {
+		return string(order.ReverseStatus)
+	}
+
+	return stringValue(order.OrderStatus)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
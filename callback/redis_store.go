@@ -0,0 +1,168 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/stremovskyy/gofondy/recorder/redis_recorder"
+)
+
+const (
+	ClaimPrefix  = "callback"
+	StatusPrefix = "callback_status"
+)
+
+type redisStore struct {
+	client  *redis.Client
+	options *redis_recorder.Options
+}
+
+// NewRedisStore creates callback store on the same connection options as redis recorder
+func NewRedisStore(options *redis_recorder.Options) Store {
+	client := redis.NewClient(
+		&redis.Options{
+			Addr:       options.Addr,
+			Password:   options.Password,
+			DB:         options.DB,
+			ClientName: "FondyCallbackStore",
+		},
+	)
+
+	statusCmd := client.Ping(context.Background())
+	if statusCmd.Err() != nil {
+		panic("failed to connect to redis server: " + statusCmd.Err().Error())
+	}
+
+	return &redisStore{client: client, options: options}
+}
+
+func (s *redisStore) Claim(ctx context.Context, key string) (bool, error) {
+	return s.client.SetNX(ctx, s.key(ClaimPrefix, key), 1, s.options.DefaultTTL).Result()
+}
+
+func (s *redisStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.key(ClaimPrefix, key)).Err()
+}
+
+// advanceScript stores "rank:status" unless stored rank is higher, TTL in milliseconds (0 keeps key forever).
+// Returns whether status was stored and the value it replaced.
+var advanceScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local rank = tonumber(string.match(current, '^(-?%d+):'))
+	if rank and rank > tonumber(ARGV[1]) then
+		return {0, current}
+	end
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[1] .. ':' .. ARGV[2], 'PX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[1] .. ':' .. ARGV[2])
+end
+return {1, current or ''}
+`)
+
+// revertScript puts previous "rank:status" back (deletes key when it is empty) only if key still holds expected value
+var revertScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] == '' then
+	redis.call('DEL', KEYS[1])
+elseif tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+func (s *redisStore) Status(ctx context.Context, orderKey string) (string, error) {
+	value, err := s.client.Get(ctx, s.key(StatusPrefix, orderKey)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return statusOf(value), nil
+}
+
+func (s *redisStore) AdvanceStatus(ctx context.Context, orderKey string, status string, rank int) (string, bool, error) {
+	ttl := s.options.DefaultTTL.Milliseconds()
+
+	result, err := advanceScript.Run(ctx, s.client, []string{s.key(StatusPrefix, orderKey)}, rank, status, ttl).Slice()
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(result) != 2 {
+		return "", false, fmt.Errorf("unexpected advance status result: %v", result)
+	}
+
+	advanced, _ := result[0].(int64)
+	previous, _ := result[1].(string)
+
+	return statusOf(previous), advanced == 1, nil
+}
+
+func (s *redisStore) RevertStatus(ctx context.Context, orderKey string, status string, previous string, previousRank int) error {
+	var previousValue string
+	if previous != "" {
+		previousValue = stored(previousRank, previous)
+	}
+
+	ttl := s.options.DefaultTTL.Milliseconds()
+
+	return revertScript.Run(ctx, s.client, []string{s.key(StatusPrefix, orderKey)}, stored(statusRank(status), status), previousValue, ttl).Err()
+}
+
+// stored returns status in "rank:status" form kept in redis
+func stored(rank int, status string) string {
+	return strconv.Itoa(rank) + ":" + status
+}
+
+// statusOf returns status part of stored "rank:status" value
+func statusOf(value string) string {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return value
+	}
+
+	return parts[1]
+}
+
+func (s *redisStore) key(prefix string, key string) string {
+	return fmt.Sprintf("%s:%s:%s", s.options.Prefix, prefix, key)
+}
@@ -28,6 +28,7 @@ const Version = "0.2.0"
 
 const (
 	FondyTimeFormat = "02.01.2006 15:04:05"
+	FondyDateFormat = "02.01.2006"
 )
 
 type FondyTransactionType string
@@ -116,3 +117,37 @@ const (
 	StatusCanceled   Status = "canceled"
 	StatusCaptured   Status = "captured"
 )
+
+// FondyRecurringPeriod period of subscription schedule
+type FondyRecurringPeriod string
+
+const (
+	FondyRecurringPeriodDay   FondyRecurringPeriod = "day"
+	FondyRecurringPeriodWeek  FondyRecurringPeriod = "week"
+	FondyRecurringPeriodMonth FondyRecurringPeriod = "month"
+)
+
+// FondySubscriptionAction action for subscription management API
+type FondySubscriptionAction string
+
+const (
+	FondySubscriptionActionStart  FondySubscriptionAction = "start"
+	FondySubscriptionActionStop   FondySubscriptionAction = "stop"
+	FondySubscriptionActionUpdate FondySubscriptionAction = "update"
+)
+
+// FondySubscriptionStatus state of subscription schedule on Fondy side
+type FondySubscriptionStatus string
+
+const (
+	FondySubscriptionStatusActive   FondySubscriptionStatus = "active"
+	FondySubscriptionStatusStopped  FondySubscriptionStatus = "stopped"
+	FondySubscriptionStatusFinished FondySubscriptionStatus = "finished"
+)
+
+func (s *FondySubscriptionStatus) String() string {
+	if s != nil {
+		return string(*s)
+	}
+	return ""
+}
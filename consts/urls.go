@@ -36,6 +36,8 @@ const (
 	Fondy3DSecureS1          FondyURL = "https://pay.fondy.eu/api/3dsecure_step1/"
 	FondySettlement          FondyURL = "https://pay.fondy.eu/api/settlement"
 	FondyPartnerClientStatus FondyURL = "https://id.fondy.ua/partner-api/v1/client/status/"
+	FondyURLReports          FondyURL = "https://api.fondy.eu/api/reports/"
+	FondyURLSubscription     FondyURL = "https://api.fondy.eu/api/subscription/"
 )
 
 func (t FondyURL) String() string {
@@ -12,14 +12,20 @@
 package gofondy
 
 import (
+	"sync"
+
 	"github.com/stremovskyy/gofondy/manager"
 	"github.com/stremovskyy/gofondy/models"
 	"github.com/stremovskyy/gofondy/recorder"
 )
 
 type gateway struct {
-	manager manager.FondyManager
-	options *models.Options
+	manager  manager.FondyManager
+	options  *models.Options
+	recorder recorder.Client
+
+	waitersMu sync.Mutex
+	waiters   map[string]*waitCall
 }
 
 func New(options *models.Options) FondyGateway {
@@ -31,7 +37,8 @@ func New(options *models.Options) FondyGateway {
 
 func NewWithRecorder(options *models.Options, recorder recorder.Client) FondyGateway {
 	return &gateway{
-		manager: manager.NewManagerWithRecorder(options, recorder),
-		options: options,
+		manager:  manager.NewManagerWithRecorder(options, recorder),
+		options:  options,
+		recorder: recorder,
 	}
 }
@@ -0,0 +1,101 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// PartialCapture captures invoiceRequest.Amount (or everything that is left when amount is zero)
+// after checking it against remaining capturable amount of the order
+func (g *fondyV1) PartialCapture(invoiceRequest *models.InvoiceRequest) (*models.CaptureResult, error) {
+	order, err := g.Status(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	before := order.Totals()
+
+	amount, err := requestedAmount(invoiceRequest, before.Capturable)
+	if err != nil {
+		return nil, fmt.Errorf("partial capture: %w", err)
+	}
+
+	captureRequest := *invoiceRequest
+	captureRequest.Amount = float64(amount) / 100
+
+	captured, err := g.Capture(&captureRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CaptureResult{Order: captured, Before: *before, After: before.AfterCapture(amount)}, nil
+}
+
+// PartialRefund refunds invoiceRequest.Amount (or everything that is left when amount is zero)
+// after checking it against remaining refundable amount of the order
+func (g *fondyV1) PartialRefund(invoiceRequest *models.InvoiceRequest) (*models.RefundResult, error) {
+	order, err := g.Status(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	before := order.Totals()
+
+	amount, err := requestedAmount(invoiceRequest, before.Refundable)
+	if err != nil {
+		return nil, fmt.Errorf("partial refund: %w", err)
+	}
+
+	refundRequest := *invoiceRequest
+	refundRequest.Amount = float64(amount) / 100
+
+	refunded, err := g.Refund(&refundRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RefundResult{Order: refunded, Before: *before, After: before.AfterRefund(amount)}, nil
+}
+
+func requestedAmount(invoiceRequest *models.InvoiceRequest, remaining int64) (int64, error) {
+	if remaining <= 0 {
+		return 0, fmt.Errorf("%w: nothing left on order", models.ErrAmountExceedsRemaining)
+	}
+
+	if invoiceRequest.Amount <= 0 {
+		return remaining, nil
+	}
+
+	amount := int64(math.Round(invoiceRequest.Amount * 100))
+	if amount > remaining {
+		return 0, fmt.Errorf("%w: requested %d, remaining %d", models.ErrAmountExceedsRemaining, amount, remaining)
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/stremovskyy/gofondy/manager"
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// replyManager answers with recorded Fondy bodies, unexpected calls panic on nil embedded manager
+type replyManager struct {
+	manager.FondyManager
+	status  string
+	capture string
+	reverse string
+}
+
+func (m *replyManager) Status(*models.FondyRequestObject, *models.MerchantAccount) (*[]byte, error) {
+	raw := []byte(m.status)
+	return &raw, nil
+}
+
+func (m *replyManager) CapturePayment(*models.FondyRequestObject, *models.MerchantAccount, *models.ReservationData) (*[]byte, error) {
+	raw := []byte(m.capture)
+	return &raw, nil
+}
+
+func (m *replyManager) RefundPayment(*models.FondyRequestObject, *models.MerchantAccount) (*[]byte, error) {
+	raw := []byte(m.reverse)
+	return &raw, nil
+}
+
+const (
+	heldOrderReply     = `{"response":{"order_id":"0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11","merchant_id":1396424,"amount":"1000","currency":"UAH","order_status":"approved","response_status":"success","actual_amount":"1000","reversal_amount":"0","additional_info":"{\"capture_status\":\"hold\"}","signature":"x"}}`
+	capturedOrderReply = `{"response":{"order_id":"0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11","merchant_id":1396424,"amount":"1000","currency":"UAH","order_status":"approved","response_status":"success","actual_amount":"600","reversal_amount":"0","additional_info":"{\"capture_status\":\"captured\",\"capture_amount\":6}","signature":"x"}}`
+	captureReply       = `{"response":{"order_id":"0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11","merchant_id":1396424,"capture_status":"captured","response_status":"success","signature":"x"}}`
+	reverseReply       = `{"response":{"order_id":"0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11","merchant_id":1396424,"reverse_status":"approved","reversal_amount":"200","currency":"UAH","transaction_id":"123","response_status":"success","signature":"x"}}`
+)
+
+func amountsRequest(amount float64) *models.InvoiceRequest {
+	return &models.InvoiceRequest{
+		InvoiceID: uuid.MustParse("0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11"),
+		Merchant:  models.NewMerchantAccount("1396424", "test", ""),
+		Amount:    amount,
+	}
+}
+
+func TestPartialCaptureTotals(t *testing.T) {
+	g := &fondyV1{manager: &replyManager{status: heldOrderReply, capture: captureReply}, options: &models.Options{}}
+
+	result, err := g.PartialCapture(amountsRequest(6))
+	if err != nil {
+		t.Fatalf("partial capture: %v", err)
+	}
+
+	if result.Before.Capturable != 1000 {
+		t.Errorf("Before.Capturable = %d, want 1000", result.Before.Capturable)
+	}
+
+	want := models.OrderTotals{Amount: 1000, Captured: 600, Actual: 600, Refundable: 600}
+	if result.After != want {
+		t.Errorf("After = %+v, want %+v", result.After, want)
+	}
+}
+
+func TestPartialRefundTotals(t *testing.T) {
+	g := &fondyV1{manager: &replyManager{status: capturedOrderReply, reverse: reverseReply}, options: &models.Options{}}
+
+	result, err := g.PartialRefund(amountsRequest(2))
+	if err != nil {
+		t.Fatalf("partial refund: %v", err)
+	}
+
+	if result.Before.Refundable != 600 {
+		t.Errorf("Before.Refundable = %d, want 600", result.Before.Refundable)
+	}
+
+	want := models.OrderTotals{Amount: 1000, Captured: 600, Reversed: 200, Actual: 400, Refundable: 400}
+	if result.After != want {
+		t.Errorf("After = %+v, want %+v", result.After, want)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// CancelHold reverses held order. Non zero invoiceRequest.Amount releases only that part of the hold,
+// zero amount releases everything that is still held.
+func (g *fondyV1) CancelHold(invoiceRequest *models.InvoiceRequest) (*models.HoldCancelResult, error) {
+	order, err := g.Status(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case order.CaptureState() == consts.FondyCaptureStatusCaptured:
+		return &models.HoldCancelResult{Outcome: models.HoldCancelAlreadyCaptured, Order: order}, nil
+	case order.CaptureState() == consts.FondyCaptureStatusReversed,
+		order.OrderStatus != nil && *order.OrderStatus == consts.StatusReversed:
+		return &models.HoldCancelResult{Outcome: models.HoldCancelAlreadyReversed, Order: order}, nil
+	case order.CaptureState() != consts.FondyCaptureStatusHold:
+		return nil, fmt.Errorf("cancel hold: order is not in hold, capture status: %q", order.CaptureState())
+	}
+
+	remaining := order.RemainingHold()
+	if remaining == 0 {
+		return &models.HoldCancelResult{Outcome: models.HoldCancelAlreadyReversed, Order: order}, nil
+	}
+
+	amount := remaining
+	if invoiceRequest.Amount > 0 {
+		amount = int64(math.Round(invoiceRequest.Amount * 100))
+		if amount > remaining {
+			return nil, fmt.Errorf("cancel hold: amount %d exceeds remaining hold %d", amount, remaining)
+		}
+	}
+
+	refundRequest := *invoiceRequest
+	refundRequest.Amount = float64(amount) / 100
+
+	reversed, err := g.Refund(&refundRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.HoldCancelResult{
+		Outcome:   models.HoldCancelReleased,
+		Released:  amount,
+		Remaining: remaining - amount,
+		Order:     reversed,
+	}
+
+	if result.Remaining > 0 {
+		result.Outcome = models.HoldCancelPartiallyReleased
+	}
+
+	return result, nil
+}
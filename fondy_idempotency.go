@@ -0,0 +1,63 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"errors"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/fondy_status"
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// replay looks up order when Hold or Payment failed with duplicate order or ambiguous transport error.
+// Existing order with the same parameters is returned flagged as replay, different one yields OrderConflictError,
+// cause is returned when there is no order at Fondy.
+func (g *fondyV1) replay(invoiceRequest *models.InvoiceRequest, request *models.FondyRequestObject, cause error) (*models.Order, error) {
+	order, err := g.Status(invoiceRequest)
+	if err != nil || order == nil || order.OrderStatus == nil ||
+		(order.ResponseStatus != nil && *order.ResponseStatus != consts.FondyResponseStatusSuccess) {
+		return nil, cause
+	}
+
+	err = models.CheckReplay(order, request)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Replay = true
+
+	return order, nil
+}
+
+// duplicateOrder reports whether Fondy rejected request because order ID is already used
+func duplicateOrder(err error) bool {
+	var fondyError *models.FondyError
+	if errors.As(err, &fondyError) {
+		return fondyError.CodeIs(fondy_status.DuplicateOrder)
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"time"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+func (g *fondyV1) Reports(reportsRequest *models.ReportsRequest) ([]models.Order, error) {
+	var orders []models.Order
+
+	it := g.ReportsIterator(reportsRequest)
+	for it.Next() {
+		orders = append(orders, *it.Order())
+	}
+
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	return orders, nil
+}
+
+func (g *fondyV1) ReportsIterator(reportsRequest *models.ReportsRequest) ReportsIterator {
+	it := &reportsIterator{v1: g, request: reportsRequest}
+
+	it.err = reportsRequest.Validate()
+	if it.err == nil {
+		it.windows = reportsRequest.Windows(g.options.ReportsWindow)
+	}
+
+	return it
+}
+
+func (g *fondyV1) reportsWindow(reportsRequest *models.ReportsRequest, from time.Time, to time.Time) ([]models.Order, error) {
+	request := &models.FondyRequestObject{
+		MerchantID: reportsRequest.GetMerchantIDString(),
+		DateFrom:   models.ReportDateString(from),
+		DateTo:     models.ReportDateString(to),
+	}
+
+	raw, err := g.manager.Reports(request, reportsRequest.Merchant)
+	if err != nil {
+		return nil, models.NewAPIError(800, "REPORTS: Http request failed", err, request, raw)
+	}
+
+	orders, err := models.UnmarshalReportsResponse(*raw)
+	if err != nil {
+		return nil, models.NewAPIError(801, "REPORTS: Unmarshal response fail", err, request, raw)
+	}
+
+	return orders, nil
+}
+
+type reportsIterator struct {
+	v1      *fondyV1
+	request *models.ReportsRequest
+	windows [][2]time.Time
+	page    []models.Order
+	current *models.Order
+	err     error
+}
+
+func (it *reportsIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || len(it.windows) == 0 {
+			it.current = nil
+			return false
+		}
+
+		window := it.windows[0]
+		it.windows = it.windows[1:]
+		it.page, it.err = it.v1.reportsWindow(it.request, window[0], window[1])
+	}
+
+	it.current = &it.page[0]
+	it.page = it.page[1:]
+
+	return true
+}
+
+func (it *reportsIterator) Order() *models.Order {
+	return it.current
+}
+
+func (it *reportsIterator) Err() error {
+	return it.err
+}
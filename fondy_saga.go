@@ -0,0 +1,353 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+	"github.com/stremovskyy/gofondy/models/models_v2"
+	"github.com/stremovskyy/gofondy/poll"
+	"github.com/stremovskyy/gofondy/saga"
+)
+
+// HoldCaptureSettle holds payment, captures it and settles it to receivers as one flow.
+// Progress is saved to options.Store after every step, calling it again for the same order resumes the flow.
+// When capture or settlement fails, already done steps are compensated by split refund and reverse.
+func (g *gateway) HoldCaptureSettle(ctx context.Context, invoiceRequest *models.InvoiceRequest, options *saga.Options) (*saga.Report, error) {
+	if options == nil {
+		options = saga.DefaultOptions()
+	}
+
+	if options.Store == nil {
+		return nil, errors.New("saga: store is required")
+	}
+
+	options = options.WithDefaults()
+
+	orderID := invoiceRequest.InvoiceID.String()
+
+	report, err := options.Store.Load(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("saga: cannot load progress: %w", err)
+	}
+
+	if report == nil {
+		report = saga.NewReport(orderID)
+	}
+
+	if report.Finished() {
+		return report, nil
+	}
+
+	// hold is always sent in idempotent mode, so hold accepted before a crash is adopted on resume
+	idempotent := *g.options
+	idempotent.Idempotent = true
+
+	flow := &sagaFlow{
+		ctx:     ctx,
+		v1:      &fondyV1{manager: g.manager, options: g.options},
+		holdV1:  &fondyV1{manager: g.manager, options: &idempotent},
+		v2:      &fondyV2{manager: g.manager, options: g.options},
+		request: invoiceRequest,
+		options: options,
+		report:  report,
+	}
+
+	flow.poller = &poller{v1: flow.v1, recorder: g.recorder}
+
+	return flow.run()
+}
+
+type sagaFlow struct {
+	ctx     context.Context
+	v1      *fondyV1
+	holdV1  *fondyV1
+	v2      *fondyV2
+	poller  *poller
+	request *models.InvoiceRequest
+	options *saga.Options
+	report  *saga.Report
+}
+
+func (f *sagaFlow) run() (*saga.Report, error) {
+	err := f.step(saga.StepHold, f.hold)
+	if err != nil {
+		return f.fail(err)
+	}
+
+	err = f.step(saga.StepWaitHold, f.waitHold)
+	if err != nil {
+		return f.compensate(err, false)
+	}
+
+	err = f.step(saga.StepCapture, f.capture)
+	if err != nil {
+		return f.compensate(err, false)
+	}
+
+	err = f.step(saga.StepWaitCapture, f.waitCapture)
+	if err != nil {
+		return f.compensate(err, false)
+	}
+
+	err = f.step(saga.StepSettle, f.settle)
+	if err != nil {
+		return f.compensate(err, true)
+	}
+
+	f.report.Completed = true
+
+	return f.report, f.save()
+}
+
+func (f *sagaFlow) step(step saga.Step, do func() (*models.Order, error)) error {
+	if f.report.Done(step) {
+		return nil
+	}
+
+	stepReport := saga.StepReport{Step: step, Status: saga.StepStatusDone, StartedAt: time.Now()}
+
+	order, err := do()
+	stepReport.FinishedAt = time.Now()
+
+	if order != nil && order.OrderStatus != nil {
+		stepReport.OrderStatus = string(*order.OrderStatus)
+	}
+
+	if err != nil {
+		stepReport.Status = saga.StepStatusFailed
+		stepReport.Error = err.Error()
+	}
+
+	f.report.Add(stepReport)
+
+	saveErr := f.save()
+	if saveErr != nil && err == nil {
+		return saveErr
+	}
+
+	return err
+}
+
+func (f *sagaFlow) save() error {
+	err := f.options.Store.Save(f.ctx, f.report)
+	if err != nil {
+		return fmt.Errorf("saga: cannot save progress: %w", err)
+	}
+
+	return nil
+}
+
+func (f *sagaFlow) fail(cause error) (*saga.Report, error) {
+	f.report.Failed = true
+
+	err := f.save()
+	if err != nil {
+		return f.report, err
+	}
+
+	return f.report, fmt.Errorf("saga: order %s failed: %w", f.report.OrderID, cause)
+}
+
+// compensate rolls back what has been done: settlement is reversed first (if it happened), then hold or payment
+func (f *sagaFlow) compensate(cause error, settleAttempted bool) (*saga.Report, error) {
+	if settleAttempted {
+		err := f.step(saga.StepCompensateSplitRefund, f.reverseSettlement)
+		if err != nil {
+			return f.fail(fmt.Errorf("%v, compensation failed: %w", cause, err))
+		}
+	}
+
+	err := f.step(saga.StepCompensateReverse, f.reversePayment)
+	if err != nil {
+		return f.fail(fmt.Errorf("%v, compensation failed: %w", cause, err))
+	}
+
+	f.report.Compensated = true
+
+	err = f.save()
+	if err != nil {
+		return f.report, err
+	}
+
+	return f.report, fmt.Errorf("saga: order %s compensated: %w", f.report.OrderID, cause)
+}
+
+func (f *sagaFlow) settledRequest() *models.InvoiceRequest {
+	request := *f.request
+	if f.options.CaptureAmount > 0 {
+		request.Amount = f.options.CaptureAmount
+	}
+
+	return &request
+}
+
+// hold is sent idempotently: on resume after a crash Fondy answers duplicate order and existing hold is adopted
+func (f *sagaFlow) hold() (*models.Order, error) {
+	return f.holdV1.Hold(f.request)
+}
+
+func (f *sagaFlow) waitHold() (*models.Order, error) {
+	return f.poll(f.options.HoldTimeout, func(order *models.Order) bool {
+		return order.CaptureState() == consts.FondyCaptureStatusHold
+	})
+}
+
+// capture adopts order already captured by a run that crashed before saving the step
+func (f *sagaFlow) capture() (*models.Order, error) {
+	order, err := f.v1.Status(f.request)
+	if err == nil && order.Captured() {
+		return order, nil
+	}
+
+	return f.v1.Capture(f.settledRequest())
+}
+
+func (f *sagaFlow) waitCapture() (*models.Order, error) {
+	return f.poll(f.options.CaptureTimeout, func(order *models.Order) bool {
+		return order.Captured()
+	})
+}
+
+// settle adopts settlement already created by a run that crashed before saving the step
+func (f *sagaFlow) settle() (*models.Order, error) {
+	existing, err := f.v2.settlementOrder(f.request)
+	if err == nil && existing != nil && existing.OrderType != nil && *existing.OrderType == models_v2.OrderTypeSettlement {
+		return nil, nil
+	}
+
+	if len(f.options.Receivers) > 0 {
+		_, err = f.v2.SplitReceivers(f.settledRequest(), f.options.Receivers)
+	} else {
+		_, err = f.v2.Split(f.settledRequest())
+	}
+
+	return nil, err
+}
+
+// reverseSettlement reverses what was settled, nothing is reversed when split failed before Fondy created settlement
+func (f *sagaFlow) reverseSettlement() (*models.Order, error) {
+	order, err := f.v2.settlementOrder(f.request)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, nil
+	}
+
+	settlement := models_v2.NewSettlementReport(order)
+	receivers := make(models_v2.Receivers, 0, len(settlement.Payouts))
+
+	// partially reversed payouts still have their remaining amount reversed
+	for _, payout := range settlement.Payouts {
+		if payout.State == models_v2.PayoutStateFailed || payout.Amount-payout.ReversalAmount <= 0 {
+			continue
+		}
+
+		receiver := payout.Receiver
+		receiver.Requisites.Amount = payout.Amount - payout.ReversalAmount
+		receivers = append(receivers, receiver)
+	}
+
+	if len(receivers) == 0 {
+		return nil, nil
+	}
+
+	report, err := f.v2.reverseReceivers(f.request, receivers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(report.Failed()) > 0 {
+		return nil, fmt.Errorf("%d receivers were not reversed", len(report.Failed()))
+	}
+
+	return nil, nil
+}
+
+// reversePayment releases hold or refunds captured payment, declined and expired orders need no compensation
+func (f *sagaFlow) reversePayment() (*models.Order, error) {
+	request := *f.request
+	request.Amount = 0
+
+	order, err := f.v1.Status(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Lifecycle() {
+	case models.LifecycleDeclined, models.LifecycleExpired:
+		return order, nil
+	}
+
+	cancel, err := f.v1.CancelHold(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cancel.Outcome != models.HoldCancelAlreadyCaptured {
+		return cancel.Order, nil
+	}
+
+	refund, err := f.v1.PartialRefund(&request)
+	if err != nil {
+		if errors.Is(err, models.ErrAmountExceedsRemaining) {
+			return cancel.Order, nil
+		}
+
+		return nil, err
+	}
+
+	return refund.Order, nil
+}
+
+// poll checks order status until ready reports true, order is declined, expired or reversed, or timeout passes
+func (f *sagaFlow) poll(timeout time.Duration, ready func(order *models.Order) bool) (*models.Order, error) {
+	ctx, cancel := context.WithTimeout(f.ctx, timeout)
+	defer cancel()
+
+	options := &poll.Options{
+		InitialInterval: f.options.PollInterval,
+		MaxInterval:     f.options.PollInterval,
+		Until:           ready,
+	}
+
+	order, err := f.poller.run(ctx, f.request, options)
+	if err != nil {
+		return order, err
+	}
+
+	if !ready(order) {
+		return order, fmt.Errorf("order is %s", *order.OrderStatus)
+	}
+
+	return order, nil
+}
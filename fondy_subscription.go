@@ -0,0 +1,111 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"errors"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+	"github.com/stremovskyy/gofondy/models/models_v2"
+	"github.com/stremovskyy/gofondy/utils"
+)
+
+func (g *fondyV2) Subscription(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error) {
+	err := invoiceRequest.RecurringData.Validate()
+	if err != nil {
+		return nil, errors.New("subscription problem: " + err.Error())
+	}
+
+	if invoiceRequest.PaymentCardToken == nil {
+		return nil, errors.New("subscription problem: token is required for subscription")
+	}
+
+	order := &models_v2.Order{
+		MerchantID:              invoiceRequest.Merchant.MerchantIDInt(),
+		Amount:                  invoiceRequest.GetAmountString(),
+		OrderID:                 invoiceRequest.GetInvoiceIDString(),
+		Currency:                utils.StringRef(string(consts.CurrencyCodeUAH)),
+		Rectoken:                invoiceRequest.PaymentCardToken,
+		ServerCallbackURL:       invoiceRequest.ServerCallbackURL,
+		SubscriptionCallbackURL: invoiceRequest.ServerCallbackURL,
+		RecurringData:           invoiceRequest.RecurringData,
+	}
+
+	raw, err := g.manager.SubscriptionPayment(order, invoiceRequest.Merchant)
+	if err != nil {
+		return nil, models.NewAPIError(800, "Http request failed", err, order, raw)
+	}
+
+	return g.subscriptionResponse(order, raw, invoiceRequest.Merchant)
+}
+
+func (g *fondyV2) SubscriptionUpdate(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error) {
+	err := invoiceRequest.RecurringData.Validate()
+	if err != nil {
+		return nil, errors.New("subscription problem: " + err.Error())
+	}
+
+	return g.manageSubscription(invoiceRequest, consts.FondySubscriptionActionUpdate, invoiceRequest.RecurringData)
+}
+
+func (g *fondyV2) SubscriptionStop(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error) {
+	return g.manageSubscription(invoiceRequest, consts.FondySubscriptionActionStop, nil)
+}
+
+func (g *fondyV2) manageSubscription(invoiceRequest *models.InvoiceRequest, action consts.FondySubscriptionAction, recurringData *models.RecurringData) (*models_v2.Order, error) {
+	order := &models_v2.Order{
+		MerchantID:    invoiceRequest.Merchant.MerchantIDInt(),
+		OrderID:       invoiceRequest.GetInvoiceIDString(),
+		Action:        action,
+		RecurringData: recurringData,
+	}
+
+	raw, err := g.manager.SubscriptionManage(order, invoiceRequest.Merchant)
+	if err != nil {
+		return nil, models.NewAPIError(800, "Http request failed", err, order, raw)
+	}
+
+	return g.subscriptionResponse(order, raw, invoiceRequest.Merchant)
+}
+
+func (g *fondyV2) subscriptionResponse(request *models_v2.Order, raw *[]byte, merchantAccount *models.MerchantAccount) (*models_v2.Order, error) {
+	fondyResponse, err := models_v2.UnmarshalResponse(*raw)
+	if err != nil {
+		return nil, models.NewAPIError(801, "Unmarshal response fail", err, request, raw)
+	}
+
+	err = g.verify(&fondyResponse, merchantAccount.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
+	err = fondyResponse.Error()
+	if err != nil {
+		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
+	}
+
+	return fondyResponse.Order()
+}
@@ -63,7 +63,7 @@ func (g *fondyV1) VerificationLink(invoiceRequest *models.InvoiceRequest) (*url.
 		MerchantID:        invoiceRequest.GetMerchantIDString(),
 		DesignID:          &invoiceRequest.Merchant.MerchantDesignID,
 		Verification:      utils.StringRef("Y"),
-		MerchantData:      utils.StringRef("/card verification"),
+		MerchantData:      utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindVerification, "", invoiceRequest.AdditionalData, g.options.MerchantDataMode)),
 		Amount:            utils.StringRef(fmt.Sprintf("%d", fondyVerificationAmount)),
 		OrderDesc:         utils.StringRef(g.options.VerificationDescription),
 		Lifetime:          utils.StringRef(lf),
@@ -118,6 +118,11 @@ func (g *fondyV1) Status(invoiceRequest *models.InvoiceRequest) (*models.Order,
 		return nil, models.NewAPIError(801, "Unmarshal response fail", err, request, raw)
 	}
 
+	err = g.verify(raw, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	return &fondyResponse.Response, nil
 }
 
@@ -126,7 +131,9 @@ func (g *fondyV1) Refund(invoiceRequest *models.InvoiceRequest) (*models.Order,
 		MerchantID:        invoiceRequest.GetMerchantIDString(),
 		Amount:            invoiceRequest.GetAmountString(),
 		OrderID:           invoiceRequest.GetInvoiceIDString(),
+		PaymentID:         invoiceRequest.GetPaymentIDString(),
 		Currency:          utils.StringRef(string(consts.CurrencyCodeUAH)),
+		Comment:           invoiceRequest.RefundComment,
 		AdditionalData:    invoiceRequest.AdditionalData,
 		ServerCallbackURL: invoiceRequest.ServerCallbackURL,
 	}
@@ -141,6 +148,11 @@ func (g *fondyV1) Refund(invoiceRequest *models.InvoiceRequest) (*models.Order,
 		return nil, models.NewAPIError(801, "REFUND: Unmarshal refund response fail", err, request, raw)
 	}
 
+	err = g.verify(raw, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
 		return nil, models.NewAPIError(802, "REFUND: fondy gate returned an error", err, request, raw)
@@ -181,14 +193,34 @@ func (g *fondyV1) Payment(invoiceRequest *models.InvoiceRequest) (*models.Order,
 		request.Rectoken = utils.StringRef(*invoiceRequest.PaymentCardToken)
 		raw, err = g.manager.StraightPayment(request, invoiceRequest.Merchant, invoiceRequest.ReservationData)
 	}
+
+	if err != nil {
+		apiErr := models.NewAPIError(800, "Http request failed while payment", err, request, raw)
+		if g.options.Idempotent {
+			return g.replay(invoiceRequest, request, apiErr)
+		}
+
+		return nil, apiErr
+	}
+
 	fondyResponse, err := models.UnmarshalStatusResponse(*raw)
 	if err != nil {
 		return nil, models.NewAPIError(801, "Unmarshal hold payment response fail", err, request, raw)
 	}
 
+	err = g.verify(raw, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
-		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
+		apiErr := models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
+		if g.options.Idempotent && duplicateOrder(err) {
+			return g.replay(invoiceRequest, request, apiErr)
+		}
+
+		return nil, apiErr
 	}
 
 	return &fondyResponse.Response, nil
@@ -228,7 +260,12 @@ func (g *fondyV1) Hold(invoiceRequest *models.InvoiceRequest) (*models.Order, er
 	}
 
 	if err != nil {
-		return nil, models.NewAPIError(800, "Http request failed while holding payment", err, request, raw)
+		apiErr := models.NewAPIError(800, "Http request failed while holding payment", err, request, raw)
+		if g.options.Idempotent {
+			return g.replay(invoiceRequest, request, apiErr)
+		}
+
+		return nil, apiErr
 	}
 
 	fondyResponse, err := models.UnmarshalStatusResponse(*raw)
@@ -236,9 +273,19 @@ func (g *fondyV1) Hold(invoiceRequest *models.InvoiceRequest) (*models.Order, er
 		return nil, models.NewAPIError(801, "Unmarshal hold payment response fail", err, request, raw)
 	}
 
+	err = g.verify(raw, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
-		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
+		apiErr := models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
+		if g.options.Idempotent && duplicateOrder(err) {
+			return g.replay(invoiceRequest, request, apiErr)
+		}
+
+		return nil, apiErr
 	}
 
 	return &fondyResponse.Response, nil
@@ -263,6 +310,11 @@ func (g *fondyV1) Capture(invoiceRequest *models.InvoiceRequest) (*models.Order,
 		return nil, models.NewAPIError(801, "Unmarshal capture response fail", err, request, raw)
 	}
 
+	err = g.verify(raw, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
 		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
@@ -293,6 +345,11 @@ func (g *fondyV1) Credit(invoiceRequest *models.InvoiceRequest) (*models.Order,
 		return nil, models.NewAPIError(801, "Unmarshal capture response fail", err, request, raw)
 	}
 
+	err = g.verify(raw, invoiceRequest.Merchant.MerchantCreditKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
 		if errors.As(err, &models.FondyError{}) {
@@ -304,3 +361,12 @@ func (g *fondyV1) Credit(invoiceRequest *models.InvoiceRequest) (*models.Order,
 
 	return &fondyResponse.Response, nil
 }
+
+// verify checks response signature when Options.VerifyResponseSignatures is enabled
+func (g *fondyV1) verify(raw *[]byte, key string) error {
+	if !g.options.VerifyResponseSignatures || raw == nil {
+		return nil
+	}
+
+	return models.VerifyResponseSignature(key, *raw)
+}
@@ -27,6 +27,7 @@ package gofondy
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/stremovskyy/gofondy/consts"
 	"github.com/stremovskyy/gofondy/manager"
@@ -58,10 +59,19 @@ func (g *fondyV2) SplitRefund(invoiceRequest *models.InvoiceRequest) (*models_v2
 		MerchantID:        invoiceRequest.Merchant.MerchantIDInt(),
 		Amount:            invoiceRequest.GetAmountString(),
 		OrderID:           invoiceRequest.GetInvoiceIDString(),
+		PaymentID:         invoiceRequest.PaymentID,
 		Currency:          utils.StringRef(string(consts.CurrencyCodeUAH)),
+		Comment:           invoiceRequest.RefundComment,
 		ServerCallbackURL: invoiceRequest.ServerCallbackURL,
 	}
 
+	receivers, err := g.SplitAllocation(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Receiver = receivers
+
 	raw, err := g.manager.SplitRefund(request, invoiceRequest.Merchant)
 	if err != nil {
 		return nil, models.NewAPIError(800, "Http request failed", err, request, raw)
@@ -72,6 +82,11 @@ func (g *fondyV2) SplitRefund(invoiceRequest *models.InvoiceRequest) (*models_v2
 		return nil, models.NewAPIError(801, "Unmarshal response fail", err, request, raw)
 	}
 
+	err = g.verify(&fondyResponse, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
 		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
@@ -96,12 +111,37 @@ func (g *fondyV2) Split(invoiceRequest *models.InvoiceRequest) (*models_v2.Order
 		return nil, errors.New("split accounts problem " + err.Error())
 	}
 
+	if len(invoiceRequest.Merchant.SplitAccounts) == 0 {
+		return nil, errors.New("split accounts problem: no split accounts")
+	}
+
+	receivers, err := g.SplitAllocation(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.SplitReceivers(invoiceRequest, receivers)
+}
+
+// SplitAllocation returns receivers with exact amounts that percentage Split would send, without sending anything
+func (g *fondyV2) SplitAllocation(invoiceRequest *models.InvoiceRequest) (models_v2.Receivers, error) {
+	receivers, err := models_v2.PercentageReceivers(invoiceRequest.AmountMinor(), invoiceRequest.Merchant.SplitAccounts, invoiceRequest.Merchant.SplitRemainderPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("order %s split accounts problem: %w", invoiceRequest.InvoiceID, err)
+	}
+
+	return receivers, nil
+}
+
+// SplitReceivers settles captured order between receivers with explicit amounts, amounts must sum up to order amount
+func (g *fondyV2) SplitReceivers(invoiceRequest *models.InvoiceRequest, receivers models_v2.Receivers) (*models_v2.Order, error) {
 	if !invoiceRequest.Merchant.IsTechnical {
 		return nil, errors.New("split accounts problem: only technical accounts can split")
 	}
 
-	if len(invoiceRequest.Merchant.SplitAccounts) == 0 {
-		return nil, errors.New("split accounts problem: no split accounts")
+	err := receivers.Validate(invoiceRequest.AmountMinor())
+	if err != nil {
+		return nil, fmt.Errorf("order %s split receivers problem: %w", invoiceRequest.InvoiceID, err)
 	}
 
 	request := &models.FondyRequestObject{
@@ -135,6 +175,15 @@ func (g *fondyV2) Split(invoiceRequest *models.InvoiceRequest) (*models_v2.Order
 		OperationID:       invoiceRequest.GetInvoiceIDString(),
 		OrderDesc:         invoiceRequest.GetDescriptionString(),
 		ServerCallbackURL: invoiceRequest.ServerCallbackURL,
+		Receiver:          receivers,
+	}
+
+	if invoiceRequest.SettlementDate != nil {
+		order.SettlementDate = models.SettlementDateString(*invoiceRequest.SettlementDate)
+
+		if !models.SettlementDateScheduled(order.SettlementDate) {
+			return nil, fmt.Errorf("order %s split problem: settlement date must be after today", invoiceRequest.InvoiceID)
+		}
 	}
 
 	raw, err := g.manager.SplitPayment(order, invoiceRequest.Merchant)
@@ -147,6 +196,11 @@ func (g *fondyV2) Split(invoiceRequest *models.InvoiceRequest) (*models_v2.Order
 		return nil, models.NewAPIError(801, "Unmarshal response fail", err, nil, raw)
 	}
 
+	err = g.verify(&fondyResponse, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, nil, raw)
+	}
+
 	err = fondyResponse.Error()
 	if err != nil {
 		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, nil, raw)
@@ -154,3 +208,198 @@ func (g *fondyV2) Split(invoiceRequest *models.InvoiceRequest) (*models_v2.Order
 
 	return fondyResponse.Order()
 }
+
+// SettlementStatus fetches settlement order and reports payout state of every receiver
+func (g *fondyV2) SettlementStatus(invoiceRequest *models.InvoiceRequest) (*models_v2.SettlementReport, error) {
+	order, err := g.settlementOrder(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, fmt.Errorf("order %s has no settlement", invoiceRequest.InvoiceID)
+	}
+
+	return models_v2.NewSettlementReport(order), nil
+}
+
+// SplitRefundReceivers reverses only given receivers shares, each receiver amount is validated against what it was settled
+func (g *fondyV2) SplitRefundReceivers(invoiceRequest *models.InvoiceRequest, receivers models_v2.Receivers) (*models_v2.SplitRefundReport, error) {
+	if len(receivers) == 0 {
+		return nil, fmt.Errorf("order %s split refund problem: no receivers", invoiceRequest.InvoiceID)
+	}
+
+	for i, receiver := range receivers {
+		err := receiver.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("order %s split refund problem: receiver %d: %w", invoiceRequest.InvoiceID, i, err)
+		}
+	}
+
+	settlement, err := g.SettlementStatus(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = settlement.RefundableShares(receivers)
+	if err != nil {
+		return nil, fmt.Errorf("order %s split refund problem: %w", invoiceRequest.InvoiceID, err)
+	}
+
+	return g.reverseReceivers(invoiceRequest, receivers)
+}
+
+func (g *fondyV2) reverseReceivers(invoiceRequest *models.InvoiceRequest, receivers models_v2.Receivers) (*models_v2.SplitRefundReport, error) {
+	request := &models_v2.Order{
+		MerchantID:        invoiceRequest.Merchant.MerchantIDInt(),
+		Amount:            utils.StringRef(strconv.FormatInt(receivers.Sum(), 10)),
+		OrderID:           invoiceRequest.GetInvoiceIDString(),
+		PaymentID:         invoiceRequest.PaymentID,
+		Currency:          utils.StringRef(string(consts.CurrencyCodeUAH)),
+		Comment:           invoiceRequest.RefundComment,
+		ServerCallbackURL: invoiceRequest.ServerCallbackURL,
+		Receiver:          receivers,
+	}
+
+	raw, err := g.manager.SplitRefund(request, invoiceRequest.Merchant)
+	if err != nil {
+		return nil, models.NewAPIError(800, "Http request failed", err, request, raw)
+	}
+
+	fondyResponse, err := models_v2.UnmarshalResponse(*raw)
+	if err != nil {
+		return nil, models.NewAPIError(801, "Unmarshal response fail", err, request, raw)
+	}
+
+	err = g.verify(&fondyResponse, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
+	err = fondyResponse.Error()
+	if err != nil {
+		return nil, models.NewAPIError(802, "Fondy Gate Response Failure", err, request, raw)
+	}
+
+	order, err := fondyResponse.Order()
+	if err != nil {
+		return nil, models.NewAPIError(801, "Unmarshal split refund order fail", err, request, raw)
+	}
+
+	return models_v2.NewSplitRefundReport(order, receivers), nil
+}
+
+// ScheduledSettlements lists split settlement orders created for payments of the period whose own
+// settlement date is still in future. Report orders are only used to find order IDs, settlement of every
+// order is fetched by protocol 2.0 status as Fondy has no bulk settlement request. Orders whose settlement
+// cannot be fetched are skipped and reported in models.OrderErrors returned together with listed settlements.
+func (g *fondyV2) ScheduledSettlements(reportsRequest *models.ReportsRequest) ([]models_v2.Order, error) {
+	reports := &fondyV1{manager: g.manager, options: g.options}
+
+	orders, err := reports.Reports(reportsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduled []models_v2.Order
+	seen := map[string]bool{}
+	failed := models.OrderErrors{}
+
+	for _, order := range orders {
+		if order.OrderID == nil || seen[order.OrderID.String()] {
+			continue
+		}
+
+		seen[order.OrderID.String()] = true
+
+		settlement, err := g.settlementOrder(&models.InvoiceRequest{InvoiceID: *order.OrderID, Merchant: reportsRequest.Merchant})
+		if err != nil {
+			failed[order.OrderID.String()] = err
+			continue
+		}
+
+		if settlement != nil && settlement.OrderType != nil && *settlement.OrderType == models_v2.OrderTypeSettlement && settlement.SettlementScheduled() {
+			scheduled = append(scheduled, *settlement)
+		}
+	}
+
+	if len(failed) > 0 {
+		return scheduled, failed
+	}
+
+	return scheduled, nil
+}
+
+// settlementOrder fetches settlement order, nil without error means order has no settlement
+func (g *fondyV2) settlementOrder(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error) {
+	request := &models_v2.Order{
+		MerchantID: invoiceRequest.Merchant.MerchantIDInt(),
+		OrderID:    invoiceRequest.GetInvoiceIDString(),
+	}
+
+	raw, err := g.manager.SettlementStatus(request, invoiceRequest.Merchant)
+	if err != nil {
+		return nil, models.NewAPIError(800, "Http request failed", err, request, raw)
+	}
+
+	fondyResponse, err := models_v2.UnmarshalResponse(*raw)
+	if err != nil {
+		return nil, models.NewAPIError(801, "Unmarshal response fail", err, request, raw)
+	}
+
+	// error responses (e.g. order has no settlement) carry no data envelope
+	if len(fondyResponse.Response.Data) == 0 {
+		return nil, nil
+	}
+
+	err = g.verify(&fondyResponse, invoiceRequest.Merchant.MerchantKey)
+	if err != nil {
+		return nil, models.NewAPIError(804, "Response signature is invalid", err, request, raw)
+	}
+
+	order, err := fondyResponse.Order()
+	if err != nil {
+		return nil, models.NewAPIError(801, "Unmarshal settlement order fail", err, request, raw)
+	}
+
+	return order, nil
+}
+
+// CancelScheduledSettlement reverses delayed settlement before any of its receivers is paid out
+func (g *fondyV2) CancelScheduledSettlement(invoiceRequest *models.InvoiceRequest) (*models_v2.SplitRefundReport, error) {
+	settlement, err := g.SettlementStatus(invoiceRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !settlement.Order.SettlementScheduled() {
+		return nil, fmt.Errorf("order %s cancel settlement problem: settlement is not scheduled for future date", invoiceRequest.InvoiceID)
+	}
+
+	receivers := make(models_v2.Receivers, 0, len(settlement.Payouts))
+
+	for _, payout := range settlement.Payouts {
+		if payout.State != models_v2.PayoutStatePending {
+			return nil, fmt.Errorf("order %s cancel settlement problem: payout %d is already %s", invoiceRequest.InvoiceID, payout.TransactionID, payout.State)
+		}
+
+		receiver := payout.Receiver
+		receiver.Requisites.Amount = payout.Amount - payout.ReversalAmount
+		receivers = append(receivers, receiver)
+	}
+
+	if len(receivers) == 0 {
+		return nil, fmt.Errorf("order %s cancel settlement problem: settlement has no receivers", invoiceRequest.InvoiceID)
+	}
+
+	return g.reverseReceivers(invoiceRequest, receivers)
+}
+
+// verify checks response signature when Options.VerifyResponseSignatures is enabled
+func (g *fondyV2) verify(response *models_v2.ResponseWrapper, key string) error {
+	if !g.options.VerifyResponseSignatures || len(response.Response.Data) == 0 {
+		return nil
+	}
+
+	return response.VerifySignature(key)
+}
@@ -0,0 +1,97 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/gofondy/manager"
+	"github.com/stremovskyy/gofondy/models"
+	"github.com/stremovskyy/gofondy/models/models_v2"
+)
+
+// settlementsManager answers reports with given orders and settlement status per order ID, missing order fails
+type settlementsManager struct {
+	manager.FondyManager
+	reports     string
+	settlements map[string]string
+}
+
+func (m *settlementsManager) Reports(*models.FondyRequestObject, *models.MerchantAccount) (*[]byte, error) {
+	raw := []byte(m.reports)
+	return &raw, nil
+}
+
+func (m *settlementsManager) SettlementStatus(order *models_v2.Order, _ *models.MerchantAccount) (*[]byte, error) {
+	settlement, ok := m.settlements[*order.OrderID]
+	if !ok {
+		return nil, errors.New("connection reset")
+	}
+
+	raw := []byte(`{"response":{"version":"2.0","data":"` + base64.StdEncoding.EncodeToString([]byte(settlement)) + `","signature":"x"}}`)
+
+	return &raw, nil
+}
+
+func TestScheduledSettlementsReturnsPartialResults(t *testing.T) {
+	const (
+		scheduledID = "0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11"
+		failingID   = "1c7d2f1f-4f6b-4c9f-8d2b-3f7b3c8e2f22"
+		paidOutID   = "2d8e3f2f-5f7c-4daf-9e3c-4f8c4d9f3f33"
+	)
+
+	future := *models.SettlementDateString(time.Now().AddDate(0, 0, 3))
+	past := *models.SettlementDateString(time.Now().AddDate(0, 0, -3))
+
+	g := &fondyV2{
+		manager: &settlementsManager{
+			reports: `{"response":[{"order_id":"` + scheduledID + `","order_status":"approved"},{"order_id":"` + failingID + `","order_status":"approved"},{"order_id":"` + paidOutID + `","order_status":"approved"}]}`,
+			settlements: map[string]string{
+				scheduledID: `{"order":{"order_id":"` + scheduledID + `","order_type":"settlement","settlement_date":"` + future + `"}}`,
+				paidOutID:   `{"order":{"order_id":"` + paidOutID + `","order_type":"settlement","settlement_date":"` + past + `"}}`,
+			},
+		},
+		options: &models.Options{ReportsWindow: 24 * time.Hour},
+	}
+
+	now := time.Now()
+	settlements, err := g.ScheduledSettlements(&models.ReportsRequest{
+		Merchant: models.NewMerchantAccount("1396424", "test", ""),
+		DateFrom: now.Add(-time.Hour),
+		DateTo:   now,
+	})
+
+	var failed models.OrderErrors
+	if !errors.As(err, &failed) || len(failed) != 1 || failed[failingID] == nil {
+		t.Fatalf("err = %v, want OrderErrors of %s", err, failingID)
+	}
+
+	if len(settlements) != 1 || *settlements[0].OrderID != scheduledID {
+		t.Errorf("settlements = %+v, want only %s", settlements, scheduledID)
+	}
+}
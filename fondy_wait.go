@@ -0,0 +1,244 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package gofondy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+	"github.com/stremovskyy/gofondy/poll"
+	"github.com/stremovskyy/gofondy/recorder"
+)
+
+// WaitForFinal polls order status with backoff until order is terminal (or options.Until is satisfied) or ctx is done.
+// Concurrent waiters on the same order share one polling loop, started with intervals of the first waiter;
+// every order it fetches is checked against each waiter's own options, so waiters return when their own
+// condition is met.
+func (g *gateway) WaitForFinal(ctx context.Context, invoiceRequest *models.InvoiceRequest, options *poll.Options) (*models.Order, error) {
+	if options == nil {
+		options = poll.DefaultOptions()
+	}
+
+	orderID := invoiceRequest.InvoiceID.String()
+	call := g.joinWait(orderID, invoiceRequest, options)
+	defer g.leaveWait(orderID, call)
+
+	for {
+		order, updated, finished, err := call.state()
+
+		if order != nil && options.Done(order) {
+			return order, nil
+		}
+
+		if finished {
+			if err == nil {
+				err = fmt.Errorf("order %s polling stopped before condition was met", orderID)
+			}
+
+			return order, err
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return order, ctx.Err()
+		}
+	}
+}
+
+func (g *gateway) joinWait(orderID string, invoiceRequest *models.InvoiceRequest, options *poll.Options) *waitCall {
+	g.waitersMu.Lock()
+	defer g.waitersMu.Unlock()
+
+	if g.waiters == nil {
+		g.waiters = make(map[string]*waitCall)
+	}
+
+	call, ok := g.waiters[orderID]
+	if !ok {
+		pollCtx, cancel := context.WithCancel(context.Background())
+		call = &waitCall{updated: make(chan struct{}), cancel: cancel}
+		g.waiters[orderID] = call
+
+		// shared loop publishes every order and runs until it is final, fails or nobody waits
+		shared := &poll.Options{
+			InitialInterval: options.InitialInterval,
+			MaxInterval:     options.MaxInterval,
+			Multiplier:      options.Multiplier,
+			Until: func(order *models.Order) bool {
+				call.publish(order, nil, false)
+				return false
+			},
+		}
+
+		p := &poller{v1: &fondyV1{manager: g.manager, options: g.options}, recorder: g.recorder}
+
+		go func() {
+			order, err := p.run(pollCtx, invoiceRequest, shared)
+			cancel()
+
+			g.waitersMu.Lock()
+			if g.waiters[orderID] == call {
+				delete(g.waiters, orderID)
+			}
+			g.waitersMu.Unlock()
+
+			call.publish(order, err, true)
+		}()
+	}
+
+	call.waiters++
+
+	return call
+}
+
+func (g *gateway) leaveWait(orderID string, call *waitCall) {
+	g.waitersMu.Lock()
+	defer g.waitersMu.Unlock()
+
+	call.waiters--
+	if call.waiters == 0 {
+		// nobody waits anymore, stop polling and let next waiter start a new loop
+		call.cancel()
+
+		if g.waiters[orderID] == call {
+			delete(g.waiters, orderID)
+		}
+	}
+}
+
+type waitCall struct {
+	cancel  context.CancelFunc
+	waiters int
+
+	mu       sync.Mutex
+	order    *models.Order
+	err      error
+	finished bool
+	// updated is closed and replaced on every published order
+	updated chan struct{}
+}
+
+func (c *waitCall) publish(order *models.Order, err error, finished bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if order != nil {
+		c.order = order
+	}
+
+	c.err, c.finished = err, finished
+	close(c.updated)
+	c.updated = make(chan struct{})
+}
+
+func (c *waitCall) state() (*models.Order, chan struct{}, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order, c.updated, c.finished, c.err
+}
+
+type poller struct {
+	v1       *fondyV1
+	recorder recorder.Client
+}
+
+// run polls order status until options are done with it. Failed status requests are retried until ctx is done,
+// Fondy failure response (e.g. order not found) stops polling with error.
+func (p *poller) run(ctx context.Context, invoiceRequest *models.InvoiceRequest, options *poll.Options) (*models.Order, error) {
+	orderID := invoiceRequest.InvoiceID.String()
+	started := time.Now()
+	interval := options.First()
+
+	var order *models.Order
+	var lastErr error
+
+	for attempt := 1; ; attempt++ {
+		current, err := p.v1.Status(invoiceRequest)
+		if err != nil {
+			lastErr = err
+		} else {
+			order, lastErr = current, nil
+		}
+
+		p.record(ctx, orderID, attempt, order, err, time.Since(started))
+
+		if err == nil && current.ResponseStatus != nil && *current.ResponseStatus != consts.FondyResponseStatusSuccess {
+			response := &models.StatusResponse{Response: *current}
+			return nil, fmt.Errorf("order %s status: %w", orderID, response.Error())
+		}
+
+		if order != nil && err == nil && options.Done(order) {
+			return order, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return order, fmt.Errorf("%w, last status check failed: %v", ctx.Err(), lastErr)
+			}
+
+			return order, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = options.Next(interval)
+	}
+}
+
+func (p *poller) record(ctx context.Context, orderID string, attempt int, order *models.Order, err error, elapsed time.Duration) {
+	if p.recorder == nil {
+		return
+	}
+
+	metrics := map[string]string{
+		"attempt": strconv.Itoa(attempt),
+		"elapsed": elapsed.String(),
+	}
+
+	if order != nil && order.OrderStatus != nil {
+		metrics["order_status"] = string(*order.OrderStatus)
+	}
+
+	if err != nil {
+		metrics["error"] = err.Error()
+	}
+
+	tags := map[string]string{"operation": "wait_for_final", "order_id": orderID}
+
+	recordErr := p.recorder.RecordMetrics(ctx, &orderID, uuid.New().String(), metrics, tags)
+	if recordErr != nil {
+		log.Printf("[ERROR] cannot record poll of order %s: %v", orderID, recordErr)
+	}
+}
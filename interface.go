@@ -12,16 +12,21 @@
 package gofondy
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/stremovskyy/gofondy/models"
 	"github.com/stremovskyy/gofondy/models/models_v2"
+	"github.com/stremovskyy/gofondy/poll"
+	"github.com/stremovskyy/gofondy/saga"
 )
 
 type FondyGateway interface {
 	V1() V1
 	V2() V2
 	ID() ID
+	HoldCaptureSettle(ctx context.Context, invoiceRequest *models.InvoiceRequest, options *saga.Options) (*saga.Report, error)
+	WaitForFinal(ctx context.Context, invoiceRequest *models.InvoiceRequest, options *poll.Options) (*models.Order, error)
 }
 
 type V1 interface {
@@ -32,11 +37,32 @@ type V1 interface {
 	Capture(invoiceRequest *models.InvoiceRequest) (*models.Order, error)
 	Refund(invoiceRequest *models.InvoiceRequest) (*models.Order, error)
 	Credit(invoiceRequest *models.InvoiceRequest) (*models.Order, error)
+	CancelHold(invoiceRequest *models.InvoiceRequest) (*models.HoldCancelResult, error)
+	PartialCapture(invoiceRequest *models.InvoiceRequest) (*models.CaptureResult, error)
+	PartialRefund(invoiceRequest *models.InvoiceRequest) (*models.RefundResult, error)
+	Reports(reportsRequest *models.ReportsRequest) ([]models.Order, error)
+	ReportsIterator(reportsRequest *models.ReportsRequest) ReportsIterator
+}
+
+// ReportsIterator walks through report orders window by window, fetching next window lazily
+type ReportsIterator interface {
+	Next() bool
+	Order() *models.Order
+	Err() error
 }
 
 type V2 interface {
 	SplitRefund(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error)
+	SplitRefundReceivers(invoiceRequest *models.InvoiceRequest, receivers models_v2.Receivers) (*models_v2.SplitRefundReport, error)
 	Split(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error)
+	SplitAllocation(invoiceRequest *models.InvoiceRequest) (models_v2.Receivers, error)
+	SplitReceivers(invoiceRequest *models.InvoiceRequest, receivers models_v2.Receivers) (*models_v2.Order, error)
+	SettlementStatus(invoiceRequest *models.InvoiceRequest) (*models_v2.SettlementReport, error)
+	ScheduledSettlements(reportsRequest *models.ReportsRequest) ([]models_v2.Order, error)
+	CancelScheduledSettlement(invoiceRequest *models.InvoiceRequest) (*models_v2.SplitRefundReport, error)
+	Subscription(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error)
+	SubscriptionUpdate(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error)
+	SubscriptionStop(invoiceRequest *models.InvoiceRequest) (*models_v2.Order, error)
 }
 
 type ID interface {
@@ -27,6 +27,7 @@ import (
 type Client interface {
 	payment(url consts.FondyURL, request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error)
 	split(url consts.FondyURL, order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
+	order(url consts.FondyURL, order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
 	withdraw(url consts.FondyURL, request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error)
 	clientStatus(status consts.FondyURL, statusRequest *models.FondyClientStatusRequest) (*[]byte, error)
 }
@@ -136,6 +137,10 @@ func (m *client) split(url consts.FondyURL, order *models_v2.Order, merchantAcco
 	return m.v2.do(url, order, false, merchantAccount, true)
 }
 
+func (m *client) order(url consts.FondyURL, order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error) {
+	return m.v2.do(url, order, false, merchantAccount, false)
+}
+
 func (m *client) clientStatus(status consts.FondyURL, statusRequest *models.FondyClientStatusRequest) (*[]byte, error) {
 	return m.id.clientStatus(status, statusRequest)
 }
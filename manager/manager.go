@@ -45,6 +45,10 @@ type FondyManager interface {
 	SplitRefund(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
 	SplitPayment(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
 	IDStatus(fondyStatusRequest *models.FondyClientStatusRequest) (*[]byte, error)
+	Reports(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount) (*[]byte, error)
+	SubscriptionPayment(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
+	SubscriptionManage(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
+	SettlementStatus(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error)
 }
 
 type manager struct {
@@ -85,7 +89,7 @@ func NewManagerWithRecorder(options *models.Options, recorder recorder.Client) F
 
 func (m *manager) HoldPayment(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error) {
 	request.Preauth = utils.StringRef("Y")
-	request.MerchantData = utils.StringRef("hold/" + merchantAccount.MerchantAddedDescription + request.AdditionalDataString())
+	request.MerchantData = utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindHold, merchantAccount.MerchantAddedDescription, request.AdditionalData, m.options.MerchantDataMode))
 	request.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
 	request.MerchantID = &merchantAccount.MerchantID
 
@@ -94,7 +98,7 @@ func (m *manager) HoldPayment(request *models.FondyRequestObject, merchantAccoun
 
 func (m *manager) StraightPayment(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error) {
 	request.Preauth = utils.StringRef("N")
-	request.MerchantData = utils.StringRef("straight/" + merchantAccount.MerchantAddedDescription + request.AdditionalDataString())
+	request.MerchantData = utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindStraight, merchantAccount.MerchantAddedDescription, request.AdditionalData, m.options.MerchantDataMode))
 	request.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
 	request.MerchantID = &merchantAccount.MerchantID
 
@@ -103,7 +107,7 @@ func (m *manager) StraightPayment(request *models.FondyRequestObject, merchantAc
 
 func (m *manager) MobileHoldPayment(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error) {
 	request.Preauth = utils.StringRef("Y")
-	request.MerchantData = utils.StringRef("mobile/hold/" + merchantAccount.MerchantAddedDescription + request.AdditionalDataString())
+	request.MerchantData = utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindMobileHold, merchantAccount.MerchantAddedDescription, request.AdditionalData, m.options.MerchantDataMode))
 	request.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
 	request.MerchantID = &merchantAccount.MerchantID
 
@@ -112,7 +116,7 @@ func (m *manager) MobileHoldPayment(request *models.FondyRequestObject, merchant
 
 func (m *manager) MobileStraightPayment(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error) {
 	request.Preauth = utils.StringRef("N")
-	request.MerchantData = utils.StringRef("mobile/straight/" + merchantAccount.MerchantAddedDescription + request.AdditionalDataString())
+	request.MerchantData = utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindMobileStraight, merchantAccount.MerchantAddedDescription, request.AdditionalData, m.options.MerchantDataMode))
 	request.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
 	request.MerchantID = &merchantAccount.MerchantID
 
@@ -120,7 +124,7 @@ func (m *manager) MobileStraightPayment(request *models.FondyRequestObject, merc
 }
 
 func (m *manager) Withdraw(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount, reservationData *models.ReservationData) (*[]byte, error) {
-	request.MerchantData = utils.StringRef("withdraw/" + merchantAccount.MerchantAddedDescription + request.AdditionalDataString())
+	request.MerchantData = utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindWithdraw, merchantAccount.MerchantAddedDescription, request.AdditionalData, m.options.MerchantDataMode))
 	request.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
 	request.MerchantID = &merchantAccount.MerchantID
 
@@ -154,3 +158,23 @@ func (m *manager) SplitRefund(order *models_v2.Order, merchantAccount *models.Me
 func (m *manager) IDStatus(fondyStatusRequest *models.FondyClientStatusRequest) (*[]byte, error) {
 	return m.client.clientStatus(consts.FondyPartnerClientStatus, fondyStatusRequest)
 }
+
+func (m *manager) Reports(request *models.FondyRequestObject, merchantAccount *models.MerchantAccount) (*[]byte, error) {
+	return m.client.payment(consts.FondyURLReports, request, merchantAccount, nil)
+}
+
+func (m *manager) SubscriptionPayment(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error) {
+	order.Subscription = utils.StringRef("Y")
+	order.MerchantData = utils.StringRef(models.EncodeMerchantData(models.MerchantDataKindSubscription, merchantAccount.MerchantAddedDescription, nil, m.options.MerchantDataMode))
+	order.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
+
+	return m.client.order(consts.FondyURLRecurring, order, merchantAccount)
+}
+
+func (m *manager) SubscriptionManage(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error) {
+	return m.client.order(consts.FondyURLSubscription, order, merchantAccount)
+}
+
+func (m *manager) SettlementStatus(order *models_v2.Order, merchantAccount *models.MerchantAccount) (*[]byte, error) {
+	return m.client.order(consts.FondyURLStatus, order, merchantAccount)
+}
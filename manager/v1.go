@@ -57,7 +57,12 @@ func (m *v1Client) do(url consts.FondyURL, request *models.FondyRequestObject, c
 	ctx := context.WithValue(context.Background(), "request_id", requestID)
 
 	if reservationData != nil {
-		request.ReservationData = reservationData.Base64Encoded()
+		encoded, err := reservationData.Encode()
+		if err != nil {
+			return nil, err
+		}
+
+		request.ReservationData = &encoded
 	}
 
 	if m.options.IsDebug {
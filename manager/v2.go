@@ -29,12 +29,10 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/google/uuid"
 
@@ -60,24 +58,6 @@ func (m *v2Client) do(url consts.FondyURL, order *models_v2.Order, credit bool,
 		order.OrderDesc = utils.StringRef(merchantAccount.MerchantString)
 	}
 
-	wholeAmount, err := strconv.ParseFloat(*order.Amount, 64)
-	if err != nil {
-		return nil, errors.New("split accounts problem: amount parse error")
-	}
-
-	splitAmountSum := 0.0
-
-	for _, splitAccount := range merchantAccount.SplitAccounts {
-		splitAmount := wholeAmount * splitAccount.SplitPercentage / 100
-		merchantReceiver := models_v2.NewMerchantReceiver(models_v2.NewMerchantRequisites(int64(splitAmount), &splitAccount.MerchantID, &splitAccount.MerchantAddedDescription))
-		order.Receiver = append(order.Receiver, *merchantReceiver)
-		splitAmountSum += splitAmount
-	}
-
-	if splitAmountSum != wholeAmount {
-		return nil, fmt.Errorf("order %s split accounts problem: split amount sum %f != whole amount %f", *order.OrderID, splitAmountSum, wholeAmount)
-	}
-
 	fondyRequest := models_v2.NewRequest(order)
 
 	if credit {
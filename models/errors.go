@@ -25,7 +25,9 @@
 package models
 
 import (
+	"sort"
 	"strconv"
+	"strings"
 )
 
 type APIError struct {
@@ -48,3 +50,27 @@ func (e APIError) Error() string {
 
 	return "HTTP error: " + e.Message + " (" + strconv.Itoa(e.Code) + ")"
 }
+
+func (e APIError) Unwrap() error {
+	return e.Err
+}
+
+// OrderErrors failures of single orders in operation over many orders, keyed by order ID.
+// Operation returning it still returns results of orders that succeeded.
+type OrderErrors map[string]error
+
+func (e OrderErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	messages := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, id+": "+e[id].Error())
+	}
+
+	return strconv.Itoa(len(e)) + " orders failed: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,48 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+// HoldCancelOutcome result of hold cancellation
+type HoldCancelOutcome string
+
+const (
+	// HoldCancelReleased whole remaining hold has been reversed
+	HoldCancelReleased HoldCancelOutcome = "released"
+	// HoldCancelPartiallyReleased part of the hold has been reversed, the rest is still held
+	HoldCancelPartiallyReleased HoldCancelOutcome = "partially_released"
+	// HoldCancelAlreadyCaptured hold was captured before, nothing to release
+	HoldCancelAlreadyCaptured HoldCancelOutcome = "already_captured"
+	// HoldCancelAlreadyReversed hold was fully reversed before, nothing to release
+	HoldCancelAlreadyReversed HoldCancelOutcome = "already_reversed"
+)
+
+type HoldCancelResult struct {
+	Outcome HoldCancelOutcome
+	// Released amount reversed by this call in minor units
+	Released int64
+	// Remaining amount still held in minor units
+	Remaining int64
+	Order     *Order
+}
@@ -13,6 +13,8 @@ package models
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,6 +31,10 @@ type InvoiceRequest struct {
 	Container            *string
 	ServerCallbackURL    *string
 	PaymentLifetime      *time.Duration
+	RecurringData        *RecurringData
+	RefundComment        *string
+	PaymentID            *int64
+	SettlementDate       *time.Time
 
 	AdditionalData map[string]string
 }
@@ -42,11 +48,27 @@ func (i *InvoiceRequest) GetInvoiceIDString() *string {
 	return &id
 }
 
+// GetAmountString returns amount in minor units as sent to Fondy by every operation.
+// Amount is rounded to the nearest kopeck, earlier versions truncated it (19.99 was sent as 1998).
 func (i *InvoiceRequest) GetAmountString() *string {
-	amount := fmt.Sprintf("%d", int64(i.Amount*100))
+	amount := fmt.Sprintf("%d", i.AmountMinor())
 	return &amount
 }
 
+// AmountMinor returns invoice amount in minor units (kopecks)
+func (i *InvoiceRequest) AmountMinor() int64 {
+	return int64(math.Round(i.Amount * 100))
+}
+
+func (i *InvoiceRequest) GetPaymentIDString() *string {
+	if i == nil || i.PaymentID == nil {
+		return nil
+	}
+
+	id := strconv.FormatInt(*i.PaymentID, 10)
+	return &id
+}
+
 func (i *InvoiceRequest) GetMerchantIDString() *string {
 	if i == nil || i.Merchant == nil {
 		return nil
@@ -0,0 +1,186 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+// ErrOperationNotAllowed is returned when gateway operation is not valid in current order lifecycle state
+var ErrOperationNotAllowed = errors.New("operation is not allowed in order state")
+
+// LifecycleState single derived state of order. Reversed means hold was released without capture,
+// Refunded means captured money was returned.
+type LifecycleState string
+
+const (
+	LifecycleUnknown           LifecycleState = "unknown"
+	LifecycleCreated           LifecycleState = "created"
+	LifecycleProcessing        LifecycleState = "processing"
+	LifecycleHeld              LifecycleState = "held"
+	LifecyclePartiallyCaptured LifecycleState = "partially_captured"
+	LifecycleCaptured          LifecycleState = "captured"
+	LifecyclePartiallyRefunded LifecycleState = "partially_refunded"
+	LifecycleRefunded          LifecycleState = "refunded"
+	LifecycleReversed          LifecycleState = "reversed"
+	LifecycleDeclined          LifecycleState = "declined"
+	LifecycleExpired           LifecycleState = "expired"
+)
+
+// Operation gateway operation that changes or reads order
+type Operation string
+
+const (
+	OperationStatus      Operation = "status"
+	OperationCapture     Operation = "capture"
+	OperationCancelHold  Operation = "cancel_hold"
+	OperationRefund      Operation = "refund"
+	OperationSplit       Operation = "split"
+	OperationSplitRefund Operation = "split_refund"
+)
+
+// LifecycleTransitions states order may move to from each state, staying in the same state is always allowed
+var LifecycleTransitions = map[LifecycleState][]LifecycleState{
+	LifecycleUnknown:           {LifecycleCreated, LifecycleProcessing, LifecycleHeld, LifecycleCaptured, LifecycleDeclined, LifecycleExpired},
+	LifecycleCreated:           {LifecycleProcessing, LifecycleHeld, LifecycleCaptured, LifecycleDeclined, LifecycleExpired},
+	LifecycleProcessing:        {LifecycleHeld, LifecycleCaptured, LifecycleDeclined, LifecycleExpired},
+	LifecycleHeld:              {LifecyclePartiallyCaptured, LifecycleCaptured, LifecycleReversed},
+	LifecyclePartiallyCaptured: {LifecyclePartiallyRefunded, LifecycleRefunded},
+	LifecycleCaptured:          {LifecyclePartiallyRefunded, LifecycleRefunded},
+	LifecyclePartiallyRefunded: {LifecycleRefunded},
+	LifecycleRefunded:          {},
+	LifecycleReversed:          {},
+	LifecycleDeclined:          {},
+	LifecycleExpired:           {},
+}
+
+// LifecycleOperations gateway operations valid in each state
+var LifecycleOperations = map[LifecycleState][]Operation{
+	LifecycleUnknown:           {OperationStatus},
+	LifecycleCreated:           {OperationStatus},
+	LifecycleProcessing:        {OperationStatus},
+	LifecycleHeld:              {OperationStatus, OperationCapture, OperationCancelHold},
+	LifecyclePartiallyCaptured: {OperationStatus, OperationRefund, OperationSplit, OperationSplitRefund},
+	LifecycleCaptured:          {OperationStatus, OperationRefund, OperationSplit, OperationSplitRefund},
+	LifecyclePartiallyRefunded: {OperationStatus, OperationRefund, OperationSplitRefund},
+	LifecycleRefunded:          {OperationStatus},
+	LifecycleReversed:          {OperationStatus},
+	LifecycleDeclined:          {OperationStatus},
+	LifecycleExpired:           {OperationStatus},
+}
+
+// Final reports whether no further transition is possible
+func (s LifecycleState) Final() bool {
+	return len(LifecycleTransitions[s]) == 0
+}
+
+func (s LifecycleState) CanTransition(to LifecycleState) bool {
+	if s == to {
+		return true
+	}
+
+	for _, state := range LifecycleTransitions[s] {
+		if state == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s LifecycleState) Allows(operation Operation) bool {
+	for _, op := range LifecycleOperations[s] {
+		if op == operation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Lifecycle derives order state from order status, capture status, reversal amount and amounts
+func (o *Order) Lifecycle() LifecycleState {
+	if o == nil || o.OrderStatus == nil {
+		return LifecycleUnknown
+	}
+
+	switch *o.OrderStatus {
+	case consts.StatusCreated:
+		return LifecycleCreated
+	case consts.StatusProcessing:
+		return LifecycleProcessing
+	case consts.StatusDeclined:
+		return LifecycleDeclined
+	case consts.StatusExpired:
+		return LifecycleExpired
+	case consts.StatusApproved, consts.StatusReversed:
+	default:
+		return LifecycleUnknown
+	}
+
+	t := o.Totals()
+
+	switch o.CaptureState() {
+	case consts.FondyCaptureStatusHold:
+		if t.Capturable == 0 {
+			return LifecycleReversed
+		}
+
+		return LifecycleHeld
+	case consts.FondyCaptureStatusReversed:
+		return LifecycleReversed
+	}
+
+	if t.Reversed > 0 {
+		if t.Reversed >= t.Captured {
+			return LifecycleRefunded
+		}
+
+		return LifecyclePartiallyRefunded
+	}
+
+	if *o.OrderStatus == consts.StatusReversed {
+		return LifecycleRefunded
+	}
+
+	if t.Captured < t.Amount {
+		return LifecyclePartiallyCaptured
+	}
+
+	return LifecycleCaptured
+}
+
+// CheckOperation returns ErrOperationNotAllowed when operation is not valid in order lifecycle state
+func (o *Order) CheckOperation(operation Operation) error {
+	state := o.Lifecycle()
+	if !state.Allows(operation) {
+		return fmt.Errorf("%w: %s in %s", ErrOperationNotAllowed, operation, state)
+	}
+
+	return nil
+}
@@ -51,21 +51,35 @@ const (
 	MerchantFlowTypePayment  MerchantFlowType = "payment"
 )
 
+// SplitRemainderPolicy decides who gets kopecks left after percentage split is rounded down
+type SplitRemainderPolicy string
+
+const (
+	// SplitRemainderFirst ties between equal remainders go to the receiver listed first
+	SplitRemainderFirst SplitRemainderPolicy = "first"
+	// SplitRemainderLargestShare ties between equal remainders go to the receiver with the largest percentage
+	SplitRemainderLargestShare SplitRemainderPolicy = "largest_share"
+	// SplitRemainderPlatform whole remainder goes to split account marked as platform
+	SplitRemainderPlatform SplitRemainderPolicy = "platform"
+)
+
 type MerchantAccount struct {
-	UUID                     uuid.UUID           `json:"uuid"`
-	Name                     string              `json:"name"`
-	MerchantString           string              `json:"merchant_string"`
-	MerchantAddedDescription string              `json:"merchant_added_description"`
-	MerchantPaymentType      MerchantPaymentType `json:"merchant_payment_type"`
-	MerchantFlowType         MerchantFlowType    `json:"merchant_flow_type"`
-	MerchantGate             MerchantGate        `json:"merchant_gate"`
-	MerchantID               string              `json:"merchant_id"`
-	MerchantKey              string              `json:"merchant_key"`
-	MerchantCreditKey        string              `json:"merchant_credit_key"`
-	MerchantDesignID         string              `json:"merchant_design_id"`
-	IsTechnical              bool                `json:"is_technical"`
-	SplitAccounts            MerchantAccounts    `json:"split_accounts"`
-	SplitPercentage          float64             `json:"split_percentage"`
+	UUID                     uuid.UUID            `json:"uuid"`
+	Name                     string               `json:"name"`
+	MerchantString           string               `json:"merchant_string"`
+	MerchantAddedDescription string               `json:"merchant_added_description"`
+	MerchantPaymentType      MerchantPaymentType  `json:"merchant_payment_type"`
+	MerchantFlowType         MerchantFlowType     `json:"merchant_flow_type"`
+	MerchantGate             MerchantGate         `json:"merchant_gate"`
+	MerchantID               string               `json:"merchant_id"`
+	MerchantKey              string               `json:"merchant_key"`
+	MerchantCreditKey        string               `json:"merchant_credit_key"`
+	MerchantDesignID         string               `json:"merchant_design_id"`
+	IsTechnical              bool                 `json:"is_technical"`
+	SplitAccounts            MerchantAccounts     `json:"split_accounts"`
+	SplitPercentage          float64              `json:"split_percentage"`
+	SplitRemainderPolicy     SplitRemainderPolicy `json:"split_remainder_policy"`
+	IsPlatform               bool                 `json:"is_platform"`
 }
 
 func NewMerchantAccount(merchantID string, merchantKey string, merchantCreditKey string) *MerchantAccount {
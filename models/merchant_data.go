@@ -0,0 +1,209 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// MerchantDataKind operation that created the order, first part of merchant_data
+type MerchantDataKind string
+
+const (
+	MerchantDataKindHold           MerchantDataKind = "hold"
+	MerchantDataKindStraight       MerchantDataKind = "straight"
+	MerchantDataKindMobileHold     MerchantDataKind = "mobile/hold"
+	MerchantDataKindMobileStraight MerchantDataKind = "mobile/straight"
+	MerchantDataKindWithdraw       MerchantDataKind = "withdraw"
+	MerchantDataKindSubscription   MerchantDataKind = "subscription"
+	MerchantDataKindVerification   MerchantDataKind = "card verification"
+)
+
+// MerchantDataMode how merchant_data is serialized
+type MerchantDataMode int
+
+const (
+	// MerchantDataModePath human readable "v2/kind/description/key:value" form
+	MerchantDataModePath MerchantDataMode = iota
+	// MerchantDataModeJSON base64 encoded JSON, safe for any values
+	MerchantDataModeJSON
+)
+
+const (
+	merchantDataPathPrefix = "v2/"
+	merchantDataJSONPrefix = "v2j/"
+)
+
+var (
+	merchantDataEscaper   = strings.NewReplacer("%", "%25", "/", "%2F", ":", "%3A")
+	merchantDataUnescaper = strings.NewReplacer("%25", "%", "%2F", "/", "%3A", ":")
+)
+
+// legacyMerchantDataKinds kinds written before versioning, longest prefixes first
+var legacyMerchantDataKinds = []MerchantDataKind{
+	MerchantDataKindMobileStraight,
+	MerchantDataKindMobileHold,
+	MerchantDataKindStraight,
+	MerchantDataKindHold,
+	MerchantDataKindWithdraw,
+	MerchantDataKindSubscription,
+}
+
+// MerchantData decoded merchant_data of order
+type MerchantData struct {
+	Version        int               `json:"v"`
+	Kind           MerchantDataKind  `json:"kind"`
+	Description    string            `json:"description,omitempty"`
+	AdditionalData map[string]string `json:"data,omitempty"`
+}
+
+// EncodeMerchantData builds deterministic merchant_data, keys of additional data are sorted
+// and separators inside values are escaped so DecodeMerchantData returns exactly the same data
+func EncodeMerchantData(kind MerchantDataKind, description string, additionalData map[string]string, mode MerchantDataMode) string {
+	if mode == MerchantDataModeJSON {
+		data := MerchantData{Version: 2, Kind: kind, Description: description, AdditionalData: additionalData}
+		b, _ := json.Marshal(data)
+
+		return merchantDataJSONPrefix + base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	keys := make([]string, 0, len(additionalData))
+	for key := range additionalData {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(merchantDataPathPrefix)
+	b.WriteString(merchantDataEscaper.Replace(string(kind)))
+	b.WriteString("/")
+	b.WriteString(merchantDataEscaper.Replace(description))
+
+	for _, key := range keys {
+		b.WriteString("/")
+		b.WriteString(merchantDataEscaper.Replace(key))
+		b.WriteString(":")
+		b.WriteString(merchantDataEscaper.Replace(additionalData[key]))
+	}
+
+	return b.String()
+}
+
+// DecodeMerchantData parses merchant_data written by any version of this library
+func DecodeMerchantData(merchantData string) (*MerchantData, error) {
+	switch {
+	case strings.HasPrefix(merchantData, merchantDataJSONPrefix):
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(merchantData, merchantDataJSONPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		var data MerchantData
+		err = json.Unmarshal(b, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		return &data, nil
+	case strings.HasPrefix(merchantData, merchantDataPathPrefix):
+		segments := strings.Split(strings.TrimPrefix(merchantData, merchantDataPathPrefix), "/")
+		if len(segments) < 2 {
+			return nil, errors.New("merchant data: kind and description are missing")
+		}
+
+		data := &MerchantData{
+			Version:     2,
+			Kind:        MerchantDataKind(merchantDataUnescaper.Replace(segments[0])),
+			Description: merchantDataUnescaper.Replace(segments[1]),
+		}
+
+		for _, segment := range segments[2:] {
+			pair := strings.SplitN(segment, ":", 2)
+			if len(pair) != 2 {
+				return nil, errors.New("merchant data: malformed pair " + segment)
+			}
+
+			if data.AdditionalData == nil {
+				data.AdditionalData = make(map[string]string)
+			}
+
+			data.AdditionalData[merchantDataUnescaper.Replace(pair[0])] = merchantDataUnescaper.Replace(pair[1])
+		}
+
+		return data, nil
+	default:
+		return decodeLegacyMerchantData(merchantData)
+	}
+}
+
+// decodeLegacyMerchantData parses unescaped "kind/description/key:value/" form, it is best effort
+// as description and values could contain separators
+func decodeLegacyMerchantData(merchantData string) (*MerchantData, error) {
+	if strings.HasPrefix(merchantData, "/"+string(MerchantDataKindVerification)) {
+		return &MerchantData{Version: 1, Kind: MerchantDataKindVerification}, nil
+	}
+
+	for _, kind := range legacyMerchantDataKinds {
+		if !strings.HasPrefix(merchantData, string(kind)+"/") {
+			continue
+		}
+
+		data := &MerchantData{Version: 1, Kind: kind}
+		var description []string
+
+		for _, segment := range strings.Split(strings.TrimPrefix(merchantData, string(kind)+"/"), "/") {
+			pair := strings.SplitN(segment, ":", 2)
+			if len(pair) == 2 {
+				if data.AdditionalData == nil {
+					data.AdditionalData = make(map[string]string)
+				}
+
+				data.AdditionalData[pair[0]] = pair[1]
+			} else if segment != "" {
+				description = append(description, segment)
+			}
+		}
+
+		data.Description = strings.Join(description, "/")
+
+		return data, nil
+	}
+
+	return nil, errors.New("merchant data: unknown format")
+}
+
+// DecodedMerchantData decodes merchant_data of order
+func (o *Order) DecodedMerchantData() (*MerchantData, error) {
+	if o == nil || o.MerchantData == nil || *o.MerchantData == "" {
+		return nil, errors.New("merchant data is empty")
+	}
+
+	return DecodeMerchantData(*o.MerchantData)
+}
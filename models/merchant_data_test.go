@@ -0,0 +1,113 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerchantDataRoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        MerchantDataKind
+		description string
+		data        map[string]string
+	}{
+		{name: "plain", kind: MerchantDataKindHold, description: "My Shop", data: map[string]string{"order": "1", "user": "7"}},
+		{name: "no data", kind: MerchantDataKindWithdraw, description: "payout"},
+		{name: "empty description", kind: MerchantDataKindStraight, data: map[string]string{"order": "1"}},
+		{name: "separators in values", kind: MerchantDataKindMobileHold, description: "a/b:c%d", data: map[string]string{"url": "https://shop/x?a=1", "k:/%": "v:/%"}},
+		{name: "unicode", kind: MerchantDataKindSubscription, description: "Магазин", data: map[string]string{"коментар": "так"}},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range []MerchantDataMode{MerchantDataModePath, MerchantDataModeJSON} {
+			encoded := EncodeMerchantData(tt.kind, tt.description, tt.data, mode)
+
+			decoded, err := DecodeMerchantData(encoded)
+			if err != nil {
+				t.Errorf("%s mode %d: decode %q: %v", tt.name, mode, encoded, err)
+				continue
+			}
+
+			want := &MerchantData{Version: 2, Kind: tt.kind, Description: tt.description, AdditionalData: tt.data}
+			if !reflect.DeepEqual(decoded, want) {
+				t.Errorf("%s mode %d: decoded %+v, want %+v", tt.name, mode, decoded, want)
+			}
+		}
+	}
+}
+
+func TestMerchantDataEncodingIsStable(t *testing.T) {
+	data := map[string]string{"z": "1", "a": "2", "m": "3", "b": "4", "y": "5"}
+
+	tests := []struct {
+		mode MerchantDataMode
+		want string
+	}{
+		{mode: MerchantDataModePath, want: "v2/hold/shop/a:2/b:4/m:3/y:5/z:1"},
+		{mode: MerchantDataModeJSON, want: "v2j/eyJ2IjoyLCJraW5kIjoiaG9sZCIsImRlc2NyaXB0aW9uIjoic2hvcCIsImRhdGEiOnsiYSI6IjIiLCJiIjoiNCIsIm0iOiIzIiwieSI6IjUiLCJ6IjoiMSJ9fQ"},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := EncodeMerchantData(MerchantDataKindHold, "shop", data, tt.mode); got != tt.want {
+				t.Fatalf("mode %d: encoded %q, want %q", tt.mode, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestDecodeLegacyMerchantData(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *MerchantData
+	}{
+		{input: "hold/My Shop/order:1/user:7/", want: &MerchantData{Version: 1, Kind: MerchantDataKindHold, Description: "My Shop", AdditionalData: map[string]string{"order": "1", "user": "7"}}},
+		{input: "mobile/straight/My Shop", want: &MerchantData{Version: 1, Kind: MerchantDataKindMobileStraight, Description: "My Shop"}},
+		{input: "mobile/hold//order:1/", want: &MerchantData{Version: 1, Kind: MerchantDataKindMobileHold, AdditionalData: map[string]string{"order": "1"}}},
+		{input: "straight/", want: &MerchantData{Version: 1, Kind: MerchantDataKindStraight}},
+		{input: "withdraw/payout", want: &MerchantData{Version: 1, Kind: MerchantDataKindWithdraw, Description: "payout"}},
+		{input: "/card verification", want: &MerchantData{Version: 1, Kind: MerchantDataKindVerification}},
+	}
+
+	for _, tt := range tests {
+		decoded, err := DecodeMerchantData(tt.input)
+		if err != nil {
+			t.Errorf("decode %q: %v", tt.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(decoded, tt.want) {
+			t.Errorf("decode %q = %+v, want %+v", tt.input, decoded, tt.want)
+		}
+	}
+
+	_, err := DecodeMerchantData("refund/x")
+	if err == nil {
+		t.Errorf("unknown merchant data format accepted")
+	}
+}
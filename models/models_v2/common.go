@@ -24,26 +24,203 @@
 
 package models_v2
 
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ReceiverType kind of settlement receiver
+type ReceiverType string
+
+const (
+	// ReceiverTypeMerchant settlement to another Fondy merchant
+	ReceiverTypeMerchant ReceiverType = "merchant"
+	// ReceiverTypeRequisites settlement to bank account (IBAN) of legal entity
+	ReceiverTypeRequisites ReceiverType = "requisites"
+	// ReceiverTypeCard settlement to card, by card number or by rectoken
+	ReceiverTypeCard ReceiverType = "card"
+)
+
 type Receiver struct {
-	Requisites Requisites `json:"requisites"`
-	Type       string     `json:"type"`
+	Requisites Requisites   `json:"requisites"`
+	Type       ReceiverType `json:"type"`
 }
 
 func NewMerchantReceiver(requisites *Requisites) *Receiver {
-	return &Receiver{Requisites: *requisites, Type: "merchant"}
+	return &Receiver{Requisites: *requisites, Type: ReceiverTypeMerchant}
+}
+
+// NewBankAccountReceiver creates receiver paid to IBAN of legal entity identified by OKPO (EDRPOU) code
+func NewBankAccountReceiver(amount int64, iban string, okpo string, jurName string, settlementDescription *string) *Receiver {
+	return &Receiver{
+		Requisites: Requisites{Amount: amount, Account: &iban, Okpo: &okpo, JurName: &jurName, SettlementDescription: settlementDescription},
+		Type:       ReceiverTypeRequisites,
+	}
+}
+
+// NewCardReceiver creates receiver paid to card number
+func NewCardReceiver(amount int64, cardNumber string, settlementDescription *string) *Receiver {
+	return &Receiver{
+		Requisites: Requisites{Amount: amount, CardNumber: &cardNumber, SettlementDescription: settlementDescription},
+		Type:       ReceiverTypeCard,
+	}
+}
+
+// NewRectokenReceiver creates receiver paid to card saved as rectoken
+func NewRectokenReceiver(amount int64, rectoken string, settlementDescription *string) *Receiver {
+	return &Receiver{
+		Requisites: Requisites{Amount: amount, Rectoken: &rectoken, SettlementDescription: settlementDescription},
+		Type:       ReceiverTypeCard,
+	}
+}
+
+// Validate checks that receiver has all requisites its type needs
+func (r *Receiver) Validate() error {
+	if r.Requisites.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+
+	switch r.Type {
+	case ReceiverTypeMerchant:
+		if empty(r.Requisites.MerchantID) {
+			return errors.New("merchant receiver: merchant_id is required")
+		}
+	case ReceiverTypeRequisites:
+		if empty(r.Requisites.Account) || !validIBAN(*r.Requisites.Account) {
+			return errors.New("requisites receiver: valid IBAN account is required")
+		}
+
+		if empty(r.Requisites.Okpo) || !digits(*r.Requisites.Okpo) || (len(*r.Requisites.Okpo) != 8 && len(*r.Requisites.Okpo) != 10) {
+			return errors.New("requisites receiver: okpo must be 8 or 10 digits")
+		}
+
+		if empty(r.Requisites.JurName) {
+			return errors.New("requisites receiver: jur_name is required")
+		}
+	case ReceiverTypeCard:
+		if empty(r.Requisites.CardNumber) == empty(r.Requisites.Rectoken) {
+			return errors.New("card receiver: exactly one of card_number or rectoken is required")
+		}
+
+		if !empty(r.Requisites.CardNumber) && !validCardNumber(*r.Requisites.CardNumber) {
+			return errors.New("card receiver: invalid card number")
+		}
+	default:
+		return errors.New("unknown receiver type " + string(r.Type))
+	}
+
+	return nil
+}
+
+// SameAs reports whether both receivers point to the same payee, amounts are ignored
+func (r *Receiver) SameAs(other *Receiver) bool {
+	if r == nil || other == nil || r.Type != other.Type {
+		return false
+	}
+
+	switch r.Type {
+	case ReceiverTypeMerchant:
+		return equal(r.Requisites.MerchantID, other.Requisites.MerchantID)
+	case ReceiverTypeRequisites:
+		return equal(r.Requisites.Account, other.Requisites.Account)
+	case ReceiverTypeCard:
+		return equal(r.Requisites.CardNumber, other.Requisites.CardNumber) && equal(r.Requisites.Rectoken, other.Requisites.Rectoken)
+	}
+
+	return false
 }
 
 type Requisites struct {
 	Amount                int64   `json:"amount"`
 	SettlementDescription *string `json:"settlement_description,omitempty"`
 	MerchantID            *string `json:"merchant_id,omitempty"` // TODO: fondy couldn't decide string or int64
-	Account               *int64  `json:"account,omitempty"`
-	Okpo                  *int64  `json:"okpo,omitempty"`
+	Account               *string `json:"account,omitempty"`
+	Okpo                  *string `json:"okpo,omitempty"`
 	JurName               *string `json:"jur_name,omitempty"`
 	Rectoken              *string `json:"rectoken,omitempty"`
-	CardNumber            *int64  `json:"card_number,omitempty"`
+	CardNumber            *string `json:"card_number,omitempty"`
 }
 
 func NewMerchantRequisites(amount int64, merchantID *string, settlementDescription *string) *Requisites {
 	return &Requisites{Amount: amount, SettlementDescription: settlementDescription, MerchantID: merchantID}
 }
+
+func empty(s *string) bool {
+	return s == nil || strings.TrimSpace(*s) == ""
+}
+
+func equal(a *string, b *string) bool {
+	if empty(a) || empty(b) {
+		return empty(a) && empty(b)
+	}
+
+	return strings.EqualFold(strings.ReplaceAll(*a, " ", ""), strings.ReplaceAll(*b, " ", ""))
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if !unicode.IsDigit(c) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// validIBAN checks IBAN length and mod 97 checksum
+func validIBAN(iban string) bool {
+	iban = strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	rearranged := iban[4:] + iban[:4]
+	var numeric strings.Builder
+
+	for _, c := range rearranged {
+		switch {
+		case c >= '0' && c <= '9':
+			numeric.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			numeric.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return false
+		}
+	}
+
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// validCardNumber checks card number length and Luhn checksum
+func validCardNumber(number string) bool {
+	number = strings.ReplaceAll(number, " ", "")
+	if len(number) < 12 || len(number) > 19 || !digits(number) {
+		return false
+	}
+
+	sum := 0
+	double := false
+
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
@@ -25,9 +25,15 @@
 package models_v2
 
 import (
+	"errors"
+
 	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
 )
 
+// OrderTypeSettlement order_type of split settlement orders
+const OrderTypeSettlement = "settlement"
+
 type OrderWrapper struct {
 	Order Order `json:"order"`
 }
@@ -60,8 +66,32 @@ type Order struct {
 	ResponseStatus      consts.FondyResponseStatus `json:"response_status,omitempty"`
 	ReverseID           *string                    `json:"reverse_id,omitempty"`
 	TransactionID       *string                    `json:"transaction_id,omitempty"`
+	Comment             *string                    `json:"comment,omitempty"`
+
+	RecurringData           *models.RecurringData          `json:"recurring_data,omitempty"`
+	Subscription            *string                        `json:"subscription,omitempty"`
+	SubscriptionCallbackURL *string                        `json:"subscription_callback_url,omitempty"`
+	Action                  consts.FondySubscriptionAction `json:"action,omitempty"`
 }
 
 func (o *Order) AddReceiver(receiver *Receiver) {
 	o.Receiver = append(o.Receiver, *receiver)
 }
+
+// SettlementScheduled reports whether settlement is delayed to a future date
+func (o *Order) SettlementScheduled() bool {
+	if o == nil {
+		return false
+	}
+
+	return models.SettlementDateScheduled(o.SettlementDate)
+}
+
+// DecodedMerchantData decodes merchant_data of order
+func (o *Order) DecodedMerchantData() (*models.MerchantData, error) {
+	if o == nil || o.MerchantData == nil || *o.MerchantData == "" {
+		return nil, errors.New("merchant data is empty")
+	}
+
+	return models.DecodeMerchantData(*o.MerchantData)
+}
@@ -0,0 +1,142 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models_v2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// Receivers list of settlement receivers, amounts are in minor units (kopecks)
+type Receivers []Receiver
+
+func (r Receivers) Sum() int64 {
+	var sum int64
+	for _, receiver := range r {
+		sum += receiver.Requisites.Amount
+	}
+
+	return sum
+}
+
+// Validate checks that every receiver has valid requisites for its type and all together they receive exactly whole amount
+func (r Receivers) Validate(wholeAmount int64) error {
+	if len(r) == 0 {
+		return errors.New("no receivers")
+	}
+
+	for i, receiver := range r {
+		err := receiver.Validate()
+		if err != nil {
+			return fmt.Errorf("receiver %d: %w", i, err)
+		}
+	}
+
+	if r.Sum() != wholeAmount {
+		return fmt.Errorf("split amount sum %d != whole amount %d", r.Sum(), wholeAmount)
+	}
+
+	return nil
+}
+
+// percentScale keeps four decimal digits of split percentage in integer math
+const percentScale = 10000
+
+// PercentageReceivers converts split accounts percentages into merchant receivers with exact amounts.
+// Shares are rounded down and the kopecks left are handed out by largest remainder, ties resolved by policy,
+// so receivers always sum up to whole amount.
+func PercentageReceivers(wholeAmount int64, accounts models.MerchantAccounts, policy models.SplitRemainderPolicy) (Receivers, error) {
+	err := accounts.Error()
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make([]int64, len(accounts))
+	remainders := make([]int64, len(accounts))
+	percents := make([]int64, len(accounts))
+	platform := -1
+	left := wholeAmount
+
+	for i, account := range accounts {
+		percents[i] = int64(math.Round(account.SplitPercentage * percentScale))
+		share := wholeAmount * percents[i]
+		amounts[i] = share / (100 * percentScale)
+		remainders[i] = share % (100 * percentScale)
+		left -= amounts[i]
+
+		if account.IsPlatform {
+			platform = i
+		}
+	}
+
+	switch policy {
+	case models.SplitRemainderPlatform:
+		if platform < 0 {
+			return nil, errors.New("remainder policy is platform, but no split account is marked as platform")
+		}
+
+		amounts[platform] += left
+	default:
+		order := make([]int, len(accounts))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.SliceStable(order, func(a, b int) bool {
+			ia, ib := order[a], order[b]
+			if remainders[ia] != remainders[ib] {
+				return remainders[ia] > remainders[ib]
+			}
+
+			if policy == models.SplitRemainderLargestShare && percents[ia] != percents[ib] {
+				return percents[ia] > percents[ib]
+			}
+
+			return ia < ib
+		})
+
+		for i := 0; left > 0; i = (i + 1) % len(order) {
+			amounts[order[i]]++
+			left--
+		}
+	}
+
+	receivers := make(Receivers, 0, len(accounts))
+
+	for i, account := range accounts {
+		account := account
+		receivers = append(receivers, *NewMerchantReceiver(NewMerchantRequisites(amounts[i], &account.MerchantID, &account.MerchantAddedDescription)))
+	}
+
+	if receivers.Sum() != wholeAmount {
+		return nil, fmt.Errorf("split amount sum %d != whole amount %d", receivers.Sum(), wholeAmount)
+	}
+
+	return receivers, nil
+}
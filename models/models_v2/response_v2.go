@@ -26,10 +26,12 @@ package models_v2
 
 import (
 	"crypto/sha1"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
 	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
 )
 
 func UnmarshalResponse(data []byte) (ResponseWrapper, error) {
@@ -48,12 +50,14 @@ type Response struct {
 	Signature string `json:"signature"`
 }
 
+// SignIsValid checks signature of data with key, empty key never validates
 func (w *ResponseWrapper) SignIsValid(key string) bool {
-	if w == nil {
+	if w == nil || key == "" {
 		return false
 	}
 
-	s := key + "|" + string(w.Response.Data)
+	// Fondy signs base64 data as it was sent, Data holds it already decoded
+	s := key + "|" + base64.StdEncoding.EncodeToString(w.Response.Data)
 	h := sha1.New()
 	h.Write([]byte(s))
 	calculated := fmt.Sprintf("%x", h.Sum(nil))
@@ -61,6 +65,20 @@ func (w *ResponseWrapper) SignIsValid(key string) bool {
 	return calculated == w.Response.Signature
 }
 
+// VerifySignature returns typed signature error when response is not signed with key
+func (w *ResponseWrapper) VerifySignature(key string) error {
+	if w.SignIsValid(key) {
+		return nil
+	}
+
+	var orderID string
+	if order, err := w.Order(); err == nil && order.OrderID != nil {
+		orderID = *order.OrderID
+	}
+
+	return &models.SignatureError{OrderID: orderID, Protocol: "2.0"}
+}
+
 func (w *ResponseWrapper) Error() error {
 	order, err := w.Order()
 	if err != nil {
@@ -0,0 +1,97 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models_v2
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// golden vector: signature was calculated independently as sha1("test|" + base64 data as sent by Fondy)
+const (
+	goldenData      = "eyJvcmRlciI6eyJvcmRlcl9pZCI6InNwbGl0LTEiLCJtZXJjaGFudF9pZCI6MTM5NjQyNCwiYW1vdW50IjoiMTAwMCIsIm9yZGVyX3N0YXR1cyI6ImFwcHJvdmVkIiwicmVzcG9uc2Vfc3RhdHVzIjoic3VjY2VzcyJ9fQo="
+	goldenSignature = "e9199a5c6a09171f19aed484abacc21127700dfb"
+)
+
+func goldenResponse(t *testing.T, signature string) *ResponseWrapper {
+	body := `{"response":{"version":"2.0","data":"` + goldenData + `","signature":"` + signature + `"}}`
+
+	wrapper, err := UnmarshalResponse([]byte(body))
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	return &wrapper
+}
+
+func TestSignIsValidGoldenVector(t *testing.T) {
+	wrapper := goldenResponse(t, goldenSignature)
+
+	if !wrapper.SignIsValid("test") {
+		t.Errorf("SignIsValid rejected golden signature")
+	}
+
+	if wrapper.SignIsValid("other") {
+		t.Errorf("SignIsValid accepted signature with wrong key")
+	}
+
+	order, err := wrapper.Order()
+	if err != nil {
+		t.Fatalf("order: %v", err)
+	}
+
+	if order.OrderID == nil || *order.OrderID != "split-1" {
+		t.Errorf("unexpected order: %+v", order)
+	}
+}
+
+func TestVerifySignatureReturnsTypedError(t *testing.T) {
+	wrapper := goldenResponse(t, "0000000000000000000000000000000000000000")
+
+	var signatureError *models.SignatureError
+	if !errors.As(wrapper.VerifySignature("test"), &signatureError) {
+		t.Fatalf("expected SignatureError")
+	}
+
+	if signatureError.OrderID != "split-1" || signatureError.Protocol != "2.0" {
+		t.Errorf("unexpected error: %+v", signatureError)
+	}
+
+	if err := goldenResponse(t, goldenSignature).VerifySignature("test"); err != nil {
+		t.Errorf("golden signature rejected: %v", err)
+	}
+}
+
+func TestSignIsValidRejectsEmptyKey(t *testing.T) {
+	signature := fmt.Sprintf("%x", sha1.Sum([]byte("|"+goldenData)))
+
+	if goldenResponse(t, signature).SignIsValid("") {
+		t.Errorf("signature calculated with empty key accepted")
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models_v2
+
+import (
+	"strings"
+)
+
+// PayoutState state of settlement payout to a single receiver
+type PayoutState string
+
+const (
+	PayoutStatePending  PayoutState = "pending"
+	PayoutStateSettled  PayoutState = "settled"
+	PayoutStateReversed PayoutState = "reversed" // settled and reversed afterwards, fully or partially
+	PayoutStateFailed   PayoutState = "failed"
+)
+
+// ReceiverPayout reconciliation of one settlement transaction, amounts are in minor units
+type ReceiverPayout struct {
+	TransactionID    int64
+	Receiver         Receiver
+	Amount           int64
+	SettlementAmount float64
+	ReversalAmount   int64
+	State            PayoutState
+	ResponseCode     string
+	Reason           string
+	PayoutTime       string
+}
+
+// SettlementReport settlement order with per receiver payout states
+type SettlementReport struct {
+	Order   *Order
+	Payouts []ReceiverPayout
+}
+
+func NewSettlementReport(order *Order) *SettlementReport {
+	report := &SettlementReport{Order: order}
+
+	for _, transaction := range order.Transaction {
+		report.Payouts = append(report.Payouts, ReceiverPayout{
+			TransactionID:    transaction.ID,
+			Receiver:         transaction.Receiver,
+			Amount:           transaction.Amount,
+			SettlementAmount: transaction.SettlementAmount,
+			ReversalAmount:   transaction.ReversalAmount,
+			State:            transaction.PayoutState(),
+			ResponseCode:     transaction.SettlementResponseCode,
+			Reason:           transaction.SettlementResponseDescription,
+			PayoutTime:       transaction.Payouttime,
+		})
+	}
+
+	return report
+}
+
+// Settled reports whether every receiver has been paid out
+func (r *SettlementReport) Settled() bool {
+	if len(r.Payouts) == 0 {
+		return false
+	}
+
+	for _, payout := range r.Payouts {
+		if payout.State != PayoutStateSettled {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *SettlementReport) Pending() []ReceiverPayout {
+	return r.filter(PayoutStatePending)
+}
+
+// Reversed returns payouts reversed after settlement, they are neither failed nor to be retried
+func (r *SettlementReport) Reversed() []ReceiverPayout {
+	return r.filter(PayoutStateReversed)
+}
+
+// Failed returns payouts that have to be retried or escalated
+func (r *SettlementReport) Failed() []ReceiverPayout {
+	return r.filter(PayoutStateFailed)
+}
+
+func (r *SettlementReport) filter(state PayoutState) []ReceiverPayout {
+	var payouts []ReceiverPayout
+
+	for _, payout := range r.Payouts {
+		if payout.State == state {
+			payouts = append(payouts, payout)
+		}
+	}
+
+	return payouts
+}
+
+// PayoutState maps Fondy settlement status of transaction to payout state
+func (t *Transaction) PayoutState() PayoutState {
+	status := t.SettlementStatus
+	if status == "" {
+		status = t.Status
+	}
+
+	switch strings.ToLower(status) {
+	case "approved", "success", "settled":
+		return PayoutStateSettled
+	case "reversed":
+		return PayoutStateReversed
+	case "declined", "failure", "failed", "error", "expired":
+		return PayoutStateFailed
+	default:
+		return PayoutStatePending
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models_v2
+
+import (
+	"testing"
+)
+
+func merchantReceiver(merchantID string, amount int64) Receiver {
+	return Receiver{Type: ReceiverTypeMerchant, Requisites: Requisites{MerchantID: &merchantID, Amount: amount}}
+}
+
+func TestTransactionPayoutState(t *testing.T) {
+	tests := []struct {
+		status string
+		state  PayoutState
+	}{
+		{status: "approved", state: PayoutStateSettled},
+		{status: "settled", state: PayoutStateSettled},
+		{status: "reversed", state: PayoutStateReversed},
+		{status: "declined", state: PayoutStateFailed},
+		{status: "expired", state: PayoutStateFailed},
+		{status: "created", state: PayoutStatePending},
+	}
+
+	for _, tt := range tests {
+		transaction := &Transaction{SettlementStatus: tt.status}
+		if state := transaction.PayoutState(); state != tt.state {
+			t.Errorf("PayoutState(%q) = %s, want %s", tt.status, state, tt.state)
+		}
+	}
+}
+
+func TestRefundableSharesOfReversedPayout(t *testing.T) {
+	report := NewSettlementReport(&Order{Transaction: []Transaction{
+		{ID: 1, SettlementStatus: "reversed", Amount: 1000, ReversalAmount: 400, Receiver: merchantReceiver("1", 1000)},
+	}})
+
+	if len(report.Reversed()) != 1 || len(report.Failed()) != 0 {
+		t.Fatalf("reversed payout reported as %s", report.Payouts[0].State)
+	}
+
+	err := report.RefundableShares(Receivers{merchantReceiver("1", 600)})
+	if err != nil {
+		t.Errorf("remaining amount of reversed payout refused: %v", err)
+	}
+
+	err = report.RefundableShares(Receivers{merchantReceiver("1", 601)})
+	if err == nil {
+		t.Errorf("amount above remaining of reversed payout accepted")
+	}
+}
+
+func TestRefundableSharesAddsUpRepeatedReceiver(t *testing.T) {
+	report := NewSettlementReport(&Order{Transaction: []Transaction{
+		{ID: 1, SettlementStatus: "approved", Amount: 1000, Receiver: merchantReceiver("1", 1000)},
+	}})
+
+	err := report.RefundableShares(Receivers{merchantReceiver("1", 600), merchantReceiver("1", 400)})
+	if err != nil {
+		t.Errorf("shares within settled amount refused: %v", err)
+	}
+
+	err = report.RefundableShares(Receivers{merchantReceiver("1", 600), merchantReceiver("1", 600)})
+	if err == nil {
+		t.Errorf("repeated receiver exceeding settled amount accepted")
+	}
+}
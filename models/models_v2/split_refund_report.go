@@ -0,0 +1,125 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models_v2
+
+import (
+	"fmt"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+// ReceiverReverse reverse of settlement to one receiver, amount is in minor units
+type ReceiverReverse struct {
+	Receiver Receiver
+	Amount   int64
+	Status   consts.FondyReverseStatus
+	Reason   string
+}
+
+func (r *ReceiverReverse) Succeeded() bool {
+	return r.Status == consts.FondyReverseStatusSuccess || r.Status == consts.FondyReverseStatusApproved
+}
+
+// SplitRefundReport split refund order with per receiver reverse status
+type SplitRefundReport struct {
+	Order     *Order
+	Receivers []ReceiverReverse
+}
+
+func NewSplitRefundReport(order *Order, receivers Receivers) *SplitRefundReport {
+	report := &SplitRefundReport{Order: order}
+
+	for _, receiver := range receivers {
+		receiver := receiver
+		reverse := ReceiverReverse{Receiver: receiver, Amount: receiver.Requisites.Amount, Status: order.ReverseStatus}
+
+		if order.ResponseDescription != nil {
+			reverse.Reason = *order.ResponseDescription
+		}
+
+		for _, transaction := range order.Transaction {
+			if transaction.Receiver.SameAs(&receiver) {
+				reverse.Status = consts.FondyReverseStatus(transaction.Status)
+				reverse.Reason = transaction.SettlementResponseDescription
+				break
+			}
+		}
+
+		report.Receivers = append(report.Receivers, reverse)
+	}
+
+	return report
+}
+
+// Failed returns receivers whose share has not been reversed
+func (r *SplitRefundReport) Failed() []ReceiverReverse {
+	var failed []ReceiverReverse
+
+	for _, reverse := range r.Receivers {
+		if !reverse.Succeeded() {
+			failed = append(failed, reverse)
+		}
+	}
+
+	return failed
+}
+
+// RefundableShares checks requested reverses against settled payouts of the settlement report.
+// Every receiver must be settled (or partially reversed) before and may be reversed for no more than it got minus what was reversed already,
+// amounts of receivers listed more than once are added up.
+func (r *SettlementReport) RefundableShares(receivers Receivers) error {
+	requested := make(map[int]int64, len(receivers))
+
+	for i, receiver := range receivers {
+		receiver := receiver
+		index := -1
+
+		for j := range r.Payouts {
+			if r.Payouts[j].Receiver.SameAs(&receiver) {
+				index = j
+				break
+			}
+		}
+
+		if index < 0 {
+			return fmt.Errorf("receiver %d: not found in settlement", i)
+		}
+
+		payout := &r.Payouts[index]
+
+		if payout.State != PayoutStateSettled && payout.State != PayoutStateReversed {
+			return fmt.Errorf("receiver %d: payout is %s", i, payout.State)
+		}
+
+		requested[index] += receiver.Requisites.Amount
+
+		available := payout.Amount - payout.ReversalAmount
+		if requested[index] > available {
+			return fmt.Errorf("receiver %d: reverse amount %d exceeds settled %d", i, requested[index], available)
+		}
+	}
+
+	return nil
+}
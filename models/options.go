@@ -36,7 +36,13 @@ type Options struct {
 	VerificationAmount      int
 	VerificationDescription string
 	VerificationLifeTime    time.Duration
-	IsDebug                 bool
+	ReportsWindow           time.Duration
+	MerchantDataMode        MerchantDataMode
+	// VerifyResponseSignatures rejects synchronous responses whose signature does not match merchant key
+	VerifyResponseSignatures bool
+	// Idempotent makes Hold and Payment look up existing order on duplicate order or ambiguous transport failure
+	Idempotent bool
+	IsDebug    bool
 }
 
 func DefaultOptions() *Options {
@@ -48,6 +54,7 @@ func DefaultOptions() *Options {
 		VerificationAmount:      1,
 		VerificationDescription: "Verification Test",
 		VerificationLifeTime:    600 * time.Second,
+		ReportsWindow:           24 * time.Hour,
 	}
 }
 
@@ -60,6 +67,7 @@ func DebugDefaultOptions() *Options {
 		VerificationAmount:      1,
 		VerificationDescription: "Verification Test",
 		VerificationLifeTime:    600 * time.Second,
+		ReportsWindow:           24 * time.Hour,
 		IsDebug:                 true,
 	}
 }
@@ -25,10 +25,8 @@
 package models
 
 import (
-	"crypto/sha1"
-	"fmt"
+	"math"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -81,13 +79,20 @@ type Order struct {
 	AdditionalInfoString    *string                      `json:"additional_info"`
 	AdditionalInfo          *AdditionalInfo              `json:"additional_info_obj"`
 	RequestId               *string                      `json:"request_id"`
+	RecurringData           *RecurringData               `json:"recurring_data"`
+	// Replay is set when order was not created by this call but found by idempotent retry
+	Replay bool `json:"-"`
 
 	additional *AdditionalInfo
 }
 
 // Additional returns additional info from order
 func (o *Order) Additional() *AdditionalInfo {
-	if o.AdditionalInfo == nil {
+	if o.AdditionalInfo != nil {
+		return o.AdditionalInfo
+	}
+
+	if o.AdditionalInfoString == nil || *o.AdditionalInfoString == "" {
 		return nil
 	}
 
@@ -104,56 +109,55 @@ func (o *Order) SignValid(merchantKey string) bool {
 		return false
 	}
 
-	s := merchantKey + "|"
+	return ParamsSignature(merchantKey, o.signatureParams()) == *o.Signature
+}
 
+// signatureParams returns order fields by parameter name, formatted as Fondy sends them
+func (o *Order) signatureParams() map[string]string {
 	values := reflect.ValueOf(*o)
 	types := values.Type()
-	preFiltered := map[string]string{}
+	params := map[string]string{}
 
 	for i := 0; i < values.NumField(); i++ {
-		if types.Field(i).Name == "Signature" || types.Field(i).Name == "ResponseSignatureString" || types.Field(i).Name == "additional" {
+		field := types.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("json") == "-" || field.Name == "AdditionalInfo" || field.Name == "RecurringData" {
 			continue
 		}
-		t := values.Field(i).Interface()
-		if t != nil {
-			s, ok := t.(*string)
-			if ok && s != nil && len(*s) > 0 {
-				preFiltered[types.Field(i).Name] = *s
-			} else if str, ok := t.(fmt.Stringer); ok && len(str.String()) > 0 {
-				preFiltered[types.Field(i).Name] = str.String()
-			} else if num, ok := t.(float64); ok {
-				preFiltered[types.Field(i).Name] = fmt.Sprintf("%.0f", num)
-			} else if dig, ok := t.(*int); ok {
-				preFiltered[types.Field(i).Name] = strconv.Itoa(*dig)
-			}
-		}
-	}
 
-	keys := make([]string, 0, len(preFiltered))
-	for k := range preFiltered {
-		keys = append(keys, k)
+		value, ok := signatureValue(values.Field(i))
+		if ok && value != "" {
+			params[strings.Split(field.Tag.Get("json"), ",")[0]] = value
+		}
 	}
 
-	sort.Strings(keys)
-
-	final := make([]string, 0, len(preFiltered))
-	for _, k := range keys {
-		final = append(final, preFiltered[k])
-	}
+	return params
+}
 
-	sk := strings.Join(final, "|")
-	s += sk
+func signatureValue(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
 
-	h := sha1.New()
-	h.Write([]byte(s))
+		if id, ok := v.Interface().(*uuid.UUID); ok {
+			return id.String(), true
+		}
 
-	createdSignature := fmt.Sprintf("%x", h.Sum(nil))
+		v = v.Elem()
+	}
 
-	if createdSignature != *o.Signature {
-		return false
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
 	}
 
-	return true
+	return "", false
 }
 
 func (o *Order) CardBinInt() *int {
@@ -174,6 +178,32 @@ func (o *Order) CardBinInt() *int {
 
 }
 
+// IsSubscription reports whether order was created by (or is a part of) subscription schedule
+func (o *Order) IsSubscription() bool {
+	if o == nil {
+		return false
+	}
+
+	return o.RecurringData != nil
+}
+
+// SubscriptionStatus returns state of subscription schedule, empty if order is not a subscription
+func (o *Order) SubscriptionStatus() consts.FondySubscriptionStatus {
+	if !o.IsSubscription() {
+		return ""
+	}
+
+	if o.RecurringData.Status != nil {
+		return *o.RecurringData.Status
+	}
+
+	if o.RecurringData.Active() {
+		return consts.FondySubscriptionStatusActive
+	}
+
+	return consts.FondySubscriptionStatusFinished
+}
+
 func (o *Order) IsVerificationTransaction() bool {
 	if o.TranType == nil {
 		return false
@@ -300,6 +330,68 @@ func (o *Order) SplitedAmount() float64 {
 	return amount / 100
 }
 
+// CaptureState returns capture status from additional info, falling back to order level field
+func (o *Order) CaptureState() consts.FondyCaptureStatus {
+	if o == nil {
+		return ""
+	}
+
+	if o.AdditionalInfo != nil && o.AdditionalInfo.CaptureStatus != "" {
+		return o.AdditionalInfo.CaptureStatus
+	}
+
+	if o.CaptureStatus != nil {
+		return *o.CaptureStatus
+	}
+
+	return ""
+}
+
+// AmountMinor returns order amount in minor units (kopecks)
+func (o *Order) AmountMinor() int64 {
+	if o == nil {
+		return 0
+	}
+
+	return minorUnits(o.Amount)
+}
+
+// ReversalAmountMinor returns reversed amount in minor units (kopecks)
+func (o *Order) ReversalAmountMinor() int64 {
+	if o == nil {
+		return 0
+	}
+
+	return minorUnits(o.ReversalAmount)
+}
+
+// RemainingHold returns held amount in minor units that is not reversed yet
+func (o *Order) RemainingHold() int64 {
+	if o.CaptureState() != consts.FondyCaptureStatusHold {
+		return 0
+	}
+
+	remaining := o.AmountMinor() - o.ReversalAmountMinor()
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+func minorUnits(s *string) int64 {
+	if s == nil || *s == "" {
+		return 0
+	}
+
+	amount, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return 0
+	}
+
+	return int64(math.Round(amount))
+}
+
 func (o *Order) CapturedAmount() float64 {
 	if o == nil || o.FeeOplata == nil {
 		return 0
@@ -0,0 +1,133 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"errors"
+	"math"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+// ErrAmountExceedsRemaining is returned when requested capture or refund amount is bigger than what is left on order
+var ErrAmountExceedsRemaining = errors.New("amount exceeds remaining")
+
+// OrderTotals running totals of order, all amounts are in minor units (kopecks)
+type OrderTotals struct {
+	Amount     int64
+	Captured   int64
+	Reversed   int64
+	Actual     int64
+	Capturable int64
+	Refundable int64
+}
+
+// Totals calculates what was captured and reversed on order and what is still available for capture or refund
+func (o *Order) Totals() *OrderTotals {
+	t := &OrderTotals{
+		Amount:   o.AmountMinor(),
+		Reversed: o.ReversalAmountMinor(),
+	}
+
+	if o == nil {
+		return t
+	}
+
+	t.Actual = minorUnits(o.ActualAmount)
+
+	switch o.CaptureState() {
+	case consts.FondyCaptureStatusHold:
+		t.Capturable = positive(t.Amount - t.Reversed)
+		return t
+	case consts.FondyCaptureStatusCaptured:
+		if o.AdditionalInfo != nil && o.AdditionalInfo.CaptureAmount > 0 {
+			t.Captured = int64(math.Round(o.AdditionalInfo.CaptureAmount * 100))
+		} else {
+			t.Captured = t.Amount
+		}
+	case consts.FondyCaptureStatusReversed:
+		return t
+	default:
+		if o.OrderStatus == nil || (*o.OrderStatus != consts.StatusApproved && *o.OrderStatus != consts.StatusReversed) {
+			return t
+		}
+
+		t.Captured = t.Amount
+	}
+
+	if t.Actual > 0 {
+		t.Refundable = t.Actual
+	} else {
+		t.Refundable = positive(t.Captured - t.Reversed)
+	}
+
+	return t
+}
+
+// CaptureResult outcome of partial capture, After is Before with captured amount applied
+// because capture reply carries neither amount nor additional info
+type CaptureResult struct {
+	Order  *Order
+	Before OrderTotals
+	After  OrderTotals
+}
+
+// RefundResult outcome of partial refund, After is Before with refunded amount applied
+// because reverse reply carries neither amounts nor capture status
+type RefundResult struct {
+	Order  *Order
+	Before OrderTotals
+	After  OrderTotals
+}
+
+// AfterCapture returns totals once amount is captured, the rest of the hold is released by Fondy
+func (t OrderTotals) AfterCapture(amount int64) OrderTotals {
+	t.Captured += amount
+	t.Capturable = 0
+	t.Actual = t.Captured
+	t.Refundable = t.Captured
+
+	return t
+}
+
+// AfterRefund returns totals once amount is refunded
+func (t OrderTotals) AfterRefund(amount int64) OrderTotals {
+	t.Reversed += amount
+	t.Refundable = positive(t.Refundable - amount)
+
+	if t.Actual > 0 {
+		t.Actual = positive(t.Actual - amount)
+	}
+
+	return t
+}
+
+func positive(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+
+	return v
+}
@@ -0,0 +1,122 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+const recurringDateFormat = "2006-01-02"
+
+// RecurringData schedule of server side subscription payments
+type RecurringData struct {
+	Every     int                             `json:"every"`
+	Period    consts.FondyRecurringPeriod     `json:"period"`
+	StartTime string                          `json:"start_time"`
+	EndTime   string                          `json:"end_time,omitempty"`
+	Amount    int64                           `json:"amount"`
+	State     *string                         `json:"state,omitempty"`
+	Readonly  *string                         `json:"readonly,omitempty"`
+	Status    *consts.FondySubscriptionStatus `json:"status,omitempty"`
+}
+
+// NewRecurringData creates schedule charging amount (in UAH) every N periods starting from start date
+func NewRecurringData(every int, period consts.FondyRecurringPeriod, amount float64, start time.Time, end *time.Time) *RecurringData {
+	r := &RecurringData{
+		Every:     every,
+		Period:    period,
+		StartTime: start.Format(recurringDateFormat),
+		Amount:    int64(math.Round(amount * 100)),
+	}
+
+	if end != nil {
+		r.EndTime = end.Format(recurringDateFormat)
+	}
+
+	return r
+}
+
+func (r *RecurringData) Validate() error {
+	if r == nil {
+		return errors.New("recurring data is empty")
+	}
+
+	if r.Every <= 0 {
+		return errors.New("recurring data: every must be positive")
+	}
+
+	switch r.Period {
+	case consts.FondyRecurringPeriodDay, consts.FondyRecurringPeriodWeek, consts.FondyRecurringPeriodMonth:
+	default:
+		return errors.New("recurring data: unknown period " + string(r.Period))
+	}
+
+	if r.Amount <= 0 {
+		return errors.New("recurring data: amount must be positive")
+	}
+
+	start, err := time.Parse(recurringDateFormat, r.StartTime)
+	if err != nil {
+		return errors.New("recurring data: invalid start_time " + r.StartTime)
+	}
+
+	if r.EndTime != "" {
+		end, err := time.Parse(recurringDateFormat, r.EndTime)
+		if err != nil {
+			return errors.New("recurring data: invalid end_time " + r.EndTime)
+		}
+
+		if !end.After(start) {
+			return errors.New("recurring data: end_time must be after start_time")
+		}
+	}
+
+	return nil
+}
+
+func (r *RecurringData) Active() bool {
+	if r == nil {
+		return false
+	}
+
+	if r.Status != nil {
+		return *r.Status == consts.FondySubscriptionStatusActive
+	}
+
+	if r.EndTime == "" {
+		return true
+	}
+
+	end, err := time.Parse(recurringDateFormat, r.EndTime)
+	if err != nil {
+		return false
+	}
+
+	return time.Now().Before(end)
+}
@@ -0,0 +1,121 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"fmt"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+// OrderConflictError existing order with the same ID was created with different parameters
+type OrderConflictError struct {
+	OrderID   string
+	Field     string
+	Requested string
+	Existing  string
+}
+
+func (e *OrderConflictError) Error() string {
+	return fmt.Sprintf("order %s already exists with different %s: requested %s, existing %s", e.OrderID, e.Field, e.Requested, e.Existing)
+}
+
+// CheckReplay compares request parameters with existing order, returns OrderConflictError on first difference.
+// Merchant, amount, currency, preauth (against capture status of approved order), transaction type and
+// rectoken are compared where both request and Fondy carry them.
+func CheckReplay(order *Order, request *FondyRequestObject) error {
+	var orderID string
+	if order.OrderID != nil {
+		orderID = order.OrderID.String()
+	}
+
+	var existingCurrency *string
+	if order.Currency != nil {
+		currency := string(*order.Currency)
+		existingCurrency = &currency
+	}
+
+	var existingMerchant *string
+	if order.MerchantID != nil {
+		merchant := fmt.Sprintf("%d", *order.MerchantID)
+		existingMerchant = &merchant
+	}
+
+	var existingTranType *string
+	if order.TranType != nil {
+		tranType := string(*order.TranType)
+		existingTranType = &tranType
+	}
+
+	checks := []struct {
+		field     string
+		requested *string
+		existing  *string
+	}{
+		{"merchant_id", request.MerchantID, existingMerchant},
+		{"amount", request.Amount, order.Amount},
+		{"currency", request.Currency, existingCurrency},
+		{"preauth", request.Preauth, existingPreauth(order)},
+		{"tran_type", purchase(request), existingTranType},
+		{"rectoken", request.Rectoken, order.Rectoken},
+	}
+
+	for _, check := range checks {
+		if check.requested == nil || *check.requested == "" || check.existing == nil {
+			continue
+		}
+
+		if *check.requested != *check.existing {
+			return &OrderConflictError{OrderID: orderID, Field: check.field, Requested: *check.requested, Existing: *check.existing}
+		}
+	}
+
+	return nil
+}
+
+// existingPreauth derives preauth flag of order from its capture status, known only once order is approved
+func existingPreauth(order *Order) *string {
+	if order.OrderStatus == nil || (*order.OrderStatus != consts.StatusApproved && *order.OrderStatus != consts.StatusReversed) {
+		return nil
+	}
+
+	preauth := "N"
+	if order.CaptureState() != "" {
+		preauth = "Y"
+	}
+
+	return &preauth
+}
+
+// purchase is transaction type of payment and hold requests, nil for other requests
+func purchase(request *FondyRequestObject) *string {
+	if request.Preauth == nil {
+		return nil
+	}
+
+	tranType := string(consts.FondyTransactionTypePurchase)
+
+	return &tranType
+}
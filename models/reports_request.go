@@ -0,0 +1,91 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/utils"
+)
+
+type ReportsRequest struct {
+	Merchant *MerchantAccount
+	DateFrom time.Time
+	DateTo   time.Time
+}
+
+func (r *ReportsRequest) Validate() error {
+	if r == nil || r.Merchant == nil {
+		return errors.New("reports request: merchant is required")
+	}
+
+	if r.DateFrom.IsZero() || r.DateTo.IsZero() {
+		return errors.New("reports request: date range is required")
+	}
+
+	if !r.DateTo.After(r.DateFrom) {
+		return errors.New("reports request: date_to must be after date_from")
+	}
+
+	return nil
+}
+
+func (r *ReportsRequest) GetMerchantIDString() *string {
+	if r == nil || r.Merchant == nil {
+		return nil
+	}
+
+	return &r.Merchant.MerchantID
+}
+
+// Windows splits requested range into sub ranges not longer than window, all in Kyiv time.
+// Fondy date_to is inclusive to the second, so each window ends one second before the next one starts.
+func (r *ReportsRequest) Windows(window time.Duration) [][2]time.Time {
+	from := r.DateFrom.In(utils.KyivLocation())
+	to := r.DateTo.In(utils.KyivLocation())
+
+	if window <= 0 {
+		return [][2]time.Time{{from, to}}
+	}
+
+	var windows [][2]time.Time
+
+	for start := from; start.Before(to); start = start.Add(window) {
+		end := start.Add(window).Add(-time.Second)
+		if !end.Before(to) {
+			end = to
+		}
+
+		windows = append(windows, [2]time.Time{start, end})
+	}
+
+	return windows
+}
+
+func ReportDateString(t time.Time) *string {
+	return utils.StringRef(t.In(utils.KyivLocation()).Format(consts.FondyTimeFormat))
+}
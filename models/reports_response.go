@@ -0,0 +1,62 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// UnmarshalReportsResponse parses reports reply, which is a list of orders on success
+// and a single failure object otherwise
+func UnmarshalReportsResponse(data []byte) ([]Order, error) {
+	var list struct {
+		Response []Order `json:"response"`
+	}
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		failure, ferr := UnmarshalStatusResponse(data)
+		if ferr != nil {
+			return nil, err
+		}
+
+		if failure.Error() != nil {
+			return nil, failure.Error()
+		}
+
+		return nil, errors.New("unexpected reports response")
+	}
+
+	for i := range list.Response {
+		if list.Response[i].AdditionalInfoString != nil && *list.Response[i].AdditionalInfoString != "" {
+			ai := AdditionalInfo{}
+			_ = json.Unmarshal([]byte(*list.Response[i].AdditionalInfoString), &ai)
+			list.Response[i].AdditionalInfo = &ai
+		}
+	}
+
+	return list.Response, nil
+}
@@ -66,10 +66,17 @@ type FondyRequestObject struct {
 	ReceiverCardNumber *string `json:"receiver_card_number,omitempty"`
 	Container          *string `json:"container,omitempty"`
 	ReservationData    *string `json:"reservation_data,omitempty"`
+	DateFrom           *string `json:"date_from,omitempty"`
+	DateTo             *string `json:"date_to,omitempty"`
+	Comment            *string `json:"comment,omitempty"`
+	PaymentID          *string `json:"payment_id,omitempty"`
 
 	AdditionalData map[string]string `json:"-"`
 }
 
+// AdditionalDataString joins additional data as "/key:val/" pairs.
+//
+// Deprecated: map order is random and separators are not escaped, use EncodeMerchantData
 func (r *FondyRequestObject) AdditionalDataString() string {
 	if r.AdditionalData == nil || len(r.AdditionalData) == 0 {
 		return ""
@@ -82,6 +89,16 @@ func (r *FondyRequestObject) AdditionalDataString() string {
 	return s
 }
 
+// signatureKey returns parameter name as Fondy sees it, signature values are ordered by it
+func signatureKey(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
+
 // Sign - adds signature for request using provided key
 func (r *FondyRequestObject) Sign(key string, isDebug bool) error {
 	if r.Signature != nil {
@@ -104,7 +121,7 @@ func (r *FondyRequestObject) Sign(key string, isDebug bool) error {
 		if t != nil {
 			s, ok := t.(*string)
 			if ok && s != nil {
-				preFiltered[types.Field(i).Name] = *s
+				preFiltered[signatureKey(types.Field(i))] = *s
 			}
 		}
 	}
@@ -25,7 +25,12 @@
 package models
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/stremovskyy/gofondy/utils"
 )
@@ -57,7 +62,117 @@ func NewReservationDataForReceiverTIN(receiverTIN *string) *ReservationData {
 	return &ReservationData{ReceiverInn: receiverTIN}
 }
 
+func NewReservationDataForPhone(phone *string) *ReservationData {
+	return &ReservationData{Phonemobile: phone}
+}
+
+func NewReservationDataForAccount(account *string) *ReservationData {
+	return &ReservationData{Account: account}
+}
+
+func NewReservationDataForUUID(uuid *string) *ReservationData {
+	return &ReservationData{Uuid: uuid}
+}
+
+func (r *ReservationData) WithPhone(phone string) *ReservationData {
+	r.Phonemobile = &phone
+	return r
+}
+
+func (r *ReservationData) WithAccount(account string) *ReservationData {
+	r.Account = &account
+	return r
+}
+
+func (r *ReservationData) WithUUID(uuid string) *ReservationData {
+	r.Uuid = &uuid
+	return r
+}
+
+func (r *ReservationData) WithReceiverTIN(receiverTIN string) *ReservationData {
+	r.ReceiverInn = &receiverTIN
+	return r
+}
+
+func (r *ReservationData) WithReceiverPan(receiverPan string) *ReservationData {
+	r.ReceiverPan = &receiverPan
+	return r
+}
+
+func (r *ReservationData) WithReceiverToken(receiverToken string) *ReservationData {
+	r.ReceiverToken = &receiverToken
+	return r
+}
+
+func (r *ReservationData) WithPaymentID(purchasePaymentId int64) *ReservationData {
+	id := strconv.FormatInt(purchasePaymentId, 10)
+	r.PurchasePaymentId = &id
+	return r
+}
+
+// Encode returns base64 encoded JSON of reservation data as Fondy expects it in requests
+func (r *ReservationData) Encode() (string, error) {
+	if r == nil {
+		return "", errors.New("reservation data is nil")
+	}
+
+	encoded, err := utils.Base64StructEncode(r)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode reservation data: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// Base64Encoded returns encoded reservation data or nil when it cannot be encoded.
+//
+// Deprecated: encoding errors are lost, use Encode
 func (r *ReservationData) Base64Encoded() *string {
-	b, _ := utils.Base64StructEncode(r)
+	b, err := r.Encode()
+	if err != nil {
+		return nil
+	}
+
 	return &b
 }
+
+// DecodeReservationData parses reservation data returned by Fondy, both base64 encoded and plain JSON forms are accepted
+func DecodeReservationData(encoded string) (*ReservationData, error) {
+	encoded = strings.TrimSpace(encoded)
+	if encoded == "" {
+		return nil, errors.New("reservation data is empty")
+	}
+
+	raw := []byte(encoded)
+
+	if !strings.HasPrefix(encoded, "{") {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode reservation data: %w", err)
+		}
+
+		raw = decoded
+	}
+
+	var r ReservationData
+	err := json.Unmarshal(raw, &r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal reservation data: %w", err)
+	}
+
+	return &r, nil
+}
+
+// DecodedReservationData decodes reservation data from additional info of order
+func (o *Order) DecodedReservationData() (*ReservationData, error) {
+	if o == nil {
+		return nil, errors.New("order is nil")
+	}
+
+	additional := o.Additional()
+	if additional == nil || additional.ReservationData == nil {
+		return nil, errors.New("order has no reservation data")
+	}
+
+	return DecodeReservationData(*additional.ReservationData)
+}
@@ -0,0 +1,54 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/utils"
+)
+
+// SettlementDateString formats delayed settlement date in Kyiv time as Fondy expects it
+func SettlementDateString(t time.Time) *string {
+	return utils.StringRef(t.In(utils.KyivLocation()).Format(consts.FondyDateFormat))
+}
+
+// SettlementDateScheduled reports whether settlement date string points to a day after today (Kyiv time)
+func SettlementDateScheduled(settlementDate *string) bool {
+	if settlementDate == nil || *settlementDate == "" {
+		return false
+	}
+
+	date, err := time.ParseInLocation(consts.FondyDateFormat, *settlementDate, utils.KyivLocation())
+	if err != nil {
+		return false
+	}
+
+	now := time.Now().In(utils.KyivLocation())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, utils.KyivLocation())
+
+	return date.After(today)
+}
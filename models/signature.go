@@ -0,0 +1,152 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stremovskyy/gofondy/consts"
+)
+
+// SignatureError response or callback signature does not match merchant key
+type SignatureError struct {
+	OrderID  string
+	Protocol string
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("invalid protocol %s signature of order %s", e.Protocol, e.OrderID)
+}
+
+// ParamsSignature calculates protocol 1.0 signature over raw request parameters:
+// sha1 of key and all non empty values ordered by parameter name, joined by "|"
+func ParamsSignature(key string, params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name, value := range params {
+		if name == "signature" || name == "response_signature_string" || value == "" {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names)+1)
+	values = append(values, key)
+
+	for _, name := range names {
+		values = append(values, params[name])
+	}
+
+	h := sha1.New()
+	h.Write([]byte(strings.Join(values, "|")))
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// ParamsSignatureValid checks signature parameter against signature calculated with merchant key.
+// Empty key never validates, anyone can calculate signature with it.
+func ParamsSignatureValid(key string, params map[string]string) bool {
+	signature, ok := params["signature"]
+	if !ok || signature == "" || key == "" {
+		return false
+	}
+
+	return ParamsSignature(key, params) == signature
+}
+
+// VerifyResponseSignature checks signature of protocol 1.0 response body with merchant key.
+// Unsuccessful responses are not signed by Fondy and are not checked.
+func VerifyResponseSignature(key string, raw []byte) error {
+	params, err := ResponseParams(raw)
+	if err != nil {
+		return err
+	}
+
+	if params["response_status"] != string(consts.FondyResponseStatusSuccess) {
+		return nil
+	}
+
+	if !ParamsSignatureValid(key, params) {
+		return &SignatureError{OrderID: params["order_id"], Protocol: "1.0"}
+	}
+
+	return nil
+}
+
+// ResponseParams returns parameters of {"response": {...}} body as strings, exactly as Fondy signed them
+func ResponseParams(raw []byte) (map[string]string, error) {
+	var wrapper struct {
+		Response json.RawMessage `json:"response"`
+	}
+
+	err := json.Unmarshal(raw, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return JSONParams(wrapper.Response)
+}
+
+// JSONParams returns fields of flat JSON object as strings, numbers keep their original form
+func JSONParams(raw []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	err := decoder.Decode(&fields)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string, len(fields))
+	for name, value := range fields {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			params[name] = v
+		case json.Number:
+			params[name] = v.String()
+		case bool:
+			params[name] = strconv.FormatBool(v)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+
+			params[name] = string(b)
+		}
+	}
+
+	return params, nil
+}
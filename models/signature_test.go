@@ -0,0 +1,119 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// golden vectors: signatures were calculated independently as sha1("test|" + non empty values ordered by parameter name)
+var signatureVectors = []struct {
+	name      string
+	body      string
+	signature string
+}{
+	{
+		name:      "numeric card_bin and response_code, uuid order_id, additional_info string",
+		body:      `{"response":{"order_id":"0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11","merchant_id":1396424,"amount":"1000","currency":"UAH","order_status":"approved","response_status":"success","card_bin":444455,"response_code":1013,"payment_id":123456789,"masked_card":"444455XXXXXX1111","additional_info":"{\"capture_status\":\"hold\"}","actual_amount":"1000","rrn":"","signature":"SIGNATURE"}}`,
+		signature: "330b4dac32e53f4209cd5baf9530f949494a4504",
+	},
+	{
+		name:      "string card_bin and empty response_code",
+		body:      `{"response":{"order_id":"0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11","merchant_id":1396424,"amount":"1000","currency":"UAH","order_status":"approved","response_status":"success","card_bin":"444455","response_code":"","payment_id":123456789,"masked_card":"444455XXXXXX1111","additional_info":"{\"capture_status\":\"hold\"}","actual_amount":"1000","rrn":"","signature":"SIGNATURE"}}`,
+		signature: "f0c59abf2fb5934341a8e94713c5a2e3c1102e38",
+	},
+}
+
+func TestOrderSignValidGoldenVectors(t *testing.T) {
+	for _, vector := range signatureVectors {
+		t.Run(vector.name, func(t *testing.T) {
+			body := strings.Replace(vector.body, "SIGNATURE", vector.signature, 1)
+
+			response, err := UnmarshalStatusResponse([]byte(body))
+			if err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if !response.Response.SignValid("test") {
+				t.Errorf("SignValid rejected golden signature")
+			}
+
+			if response.Response.SignValid("other") {
+				t.Errorf("SignValid accepted signature with wrong key")
+			}
+
+			amount := "999"
+			response.Response.Amount = &amount
+			if response.Response.SignValid("test") {
+				t.Errorf("SignValid accepted tampered amount")
+			}
+		})
+	}
+}
+
+func TestVerifyResponseSignatureGoldenVectors(t *testing.T) {
+	for _, vector := range signatureVectors {
+		t.Run(vector.name, func(t *testing.T) {
+			body := strings.Replace(vector.body, "SIGNATURE", vector.signature, 1)
+
+			err := VerifyResponseSignature("test", []byte(body))
+			if err != nil {
+				t.Errorf("golden signature rejected: %v", err)
+			}
+
+			tampered := strings.Replace(body, `"amount":"1000"`, `"amount":"999"`, 1)
+
+			var signatureError *SignatureError
+			err = VerifyResponseSignature("test", []byte(tampered))
+			if !errors.As(err, &signatureError) {
+				t.Fatalf("expected SignatureError, got %v", err)
+			}
+
+			if signatureError.OrderID != "0b6c1f0e-3f5a-4b8e-9c1a-2f6a2b7d1e11" {
+				t.Errorf("unexpected order in error: %s", signatureError.OrderID)
+			}
+		})
+	}
+}
+
+func TestVerifyResponseSignatureSkipsFailureResponses(t *testing.T) {
+	body := `{"response":{"response_status":"failure","error_code":1013,"error_message":"Duplicate order"}}`
+
+	err := VerifyResponseSignature("test", []byte(body))
+	if err != nil {
+		t.Errorf("unsigned failure response must not be rejected: %v", err)
+	}
+}
+
+func TestParamsSignatureValidRejectsEmptyKey(t *testing.T) {
+	params := map[string]string{"order_id": "1", "order_status": "approved", "amount": "1000"}
+	params["signature"] = ParamsSignature("", params)
+
+	if ParamsSignatureValid("", params) {
+		t.Errorf("signature calculated with empty key accepted")
+	}
+}
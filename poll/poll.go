@@ -0,0 +1,118 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package poll
+
+import (
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// MinInterval lower bound of delay between status checks, protects Fondy from tight loops on zero options
+const MinInterval = 100 * time.Millisecond
+
+// Options of order status polling
+type Options struct {
+	// InitialInterval delay before the second status check
+	InitialInterval time.Duration
+	// MaxInterval upper bound of delay between checks
+	MaxInterval time.Duration
+	// Multiplier growth of delay after every check, values below 1 keep delay constant
+	Multiplier float64
+	// Until target predicate, Terminal is used when nil. Polling stops on Final orders regardless of it.
+	Until func(order *models.Order) bool
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		InitialInterval: time.Second,
+		MaxInterval:     15 * time.Second,
+		Multiplier:      1.5,
+	}
+}
+
+// First returns delay before the second status check
+func (o *Options) First() time.Duration {
+	return o.bound(o.InitialInterval)
+}
+
+// Next returns delay after given one
+func (o *Options) Next(interval time.Duration) time.Duration {
+	if o.Multiplier > 1 {
+		interval = time.Duration(float64(interval) * o.Multiplier)
+	}
+
+	return o.bound(interval)
+}
+
+func (o *Options) bound(interval time.Duration) time.Duration {
+	if o.MaxInterval > 0 && interval > o.MaxInterval {
+		interval = o.MaxInterval
+	}
+
+	if interval < MinInterval {
+		return MinInterval
+	}
+
+	return interval
+}
+
+// Done reports whether polling of order should stop
+func (o *Options) Done(order *models.Order) bool {
+	if Final(order) {
+		return true
+	}
+
+	if o.Until != nil {
+		return o.Until(order)
+	}
+
+	return Terminal(order)
+}
+
+// Terminal reports whether order reached approved, declined, expired or reversed status
+func Terminal(order *models.Order) bool {
+	return Final(order) || hasStatus(order, consts.StatusApproved)
+}
+
+// Final reports whether order can not change anymore: declined, expired or reversed
+func Final(order *models.Order) bool {
+	return hasStatus(order, consts.StatusDeclined, consts.StatusExpired, consts.StatusReversed)
+}
+
+func hasStatus(order *models.Order, statuses ...consts.Status) bool {
+	if order == nil || order.OrderStatus == nil {
+		return false
+	}
+
+	for _, status := range statuses {
+		if *order.OrderStatus == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"time"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+type OperationType string
+
+const (
+	OperationPayment OperationType = "payment"
+	OperationHold    OperationType = "hold"
+	OperationCapture OperationType = "capture"
+	OperationRefund  OperationType = "refund"
+	OperationCredit  OperationType = "credit"
+	OperationSettle  OperationType = "settle"
+)
+
+// Operation one operation of local ledger, amount is in minor units (kopecks)
+type Operation struct {
+	OrderID  string
+	Type     OperationType
+	Amount   int64
+	Currency string
+	// Status expected Fondy order status, not compared when empty
+	Status string
+}
+
+// Ledger source of expected operations, implemented by application
+type Ledger interface {
+	Operations(ctx context.Context, from time.Time, to time.Time) ([]Operation, error)
+}
+
+// Fondy view of orders, gofondy V1 implements it
+type Fondy interface {
+	Status(invoiceRequest *models.InvoiceRequest) (*models.Order, error)
+	Reports(reportsRequest *models.ReportsRequest) ([]models.Order, error)
+}
+
+type Options struct {
+	Merchant *models.MerchantAccount
+	From     time.Time
+	To       time.Time
+	// StatusFallback checks orders missing in reports one by one via Status before reporting them missing at Fondy
+	StatusFallback bool
+}
+
+// expected aggregated ledger view of one order
+type expected struct {
+	orderID  string
+	currency string
+	charged  int64
+	held     int64
+	refunded int64
+	settled  int64
+	status   string
+}
+
+func aggregate(operations []Operation) (map[string]*expected, []string) {
+	orders := make(map[string]*expected)
+	var ids []string
+
+	for _, op := range operations {
+		e, ok := orders[op.OrderID]
+		if !ok {
+			e = &expected{orderID: op.OrderID}
+			orders[op.OrderID] = e
+			ids = append(ids, op.OrderID)
+		}
+
+		if op.Currency != "" {
+			e.currency = op.Currency
+		}
+
+		if op.Status != "" {
+			e.status = op.Status
+		}
+
+		switch op.Type {
+		case OperationPayment, OperationCapture, OperationCredit:
+			e.charged += op.Amount
+		case OperationHold:
+			e.held += op.Amount
+		case OperationRefund:
+			e.refunded += op.Amount
+		case OperationSettle:
+			e.settled += op.Amount
+		}
+	}
+
+	return orders, ids
+}
+
+// amount what ledger expects Fondy to show as order amount: captured, or held when nothing was captured
+func (e *expected) amount() int64 {
+	if e.charged > 0 {
+		return e.charged
+	}
+
+	return e.held
+}
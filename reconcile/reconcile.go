@@ -0,0 +1,177 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/fondy_status"
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// Reconcile compares ledger operations of the period with Fondy reports and returns differences
+func Reconcile(ctx context.Context, ledger Ledger, fondy Fondy, options *Options) (*Report, error) {
+	if options == nil || options.Merchant == nil {
+		return nil, errors.New("reconcile: merchant is required")
+	}
+
+	operations, err := ledger.Operations(ctx, options.From, options.To)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile: cannot load ledger: %w", err)
+	}
+
+	orders, err := fondy.Reports(&models.ReportsRequest{Merchant: options.Merchant, DateFrom: options.From, DateTo: options.To})
+	if err != nil {
+		return nil, fmt.Errorf("reconcile: cannot load reports: %w", err)
+	}
+
+	actual := make(map[string]*models.Order, len(orders))
+	for i := range orders {
+		if orders[i].OrderID != nil {
+			actual[orders[i].OrderID.String()] = &orders[i]
+		}
+	}
+
+	report := &Report{From: options.From, To: options.To}
+	expectedOrders, ids := aggregate(operations)
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+
+		order, ok := actual[id]
+		if !ok && options.StatusFallback {
+			order, err = status(fondy, options.Merchant, id)
+			if err != nil {
+				report.add(Difference{Kind: KindStatusCheckFailed, OrderID: id, Field: "order_status", Expected: expectedOrders[id].status, Actual: err.Error()})
+				continue
+			}
+		}
+
+		if order == nil {
+			report.add(Difference{Kind: KindMissingAtFondy, OrderID: id, Field: "amount", Expected: minor(expectedOrders[id].amount())})
+			continue
+		}
+
+		if !report.compare(expectedOrders[id], order) {
+			report.Matched++
+		}
+	}
+
+	for id, order := range actual {
+		if _, ok := expectedOrders[id]; !ok {
+			report.add(Difference{Kind: KindMissingLocally, OrderID: id, Field: "amount", Actual: minor(order.AmountMinor())})
+		}
+	}
+
+	report.sort()
+
+	return report, nil
+}
+
+// status returns order from Fondy, nil when Fondy does not know it, errors mean the order could not be checked
+func status(fondy Fondy, merchant *models.MerchantAccount, orderID string) (*models.Order, error) {
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, nil
+	}
+
+	order, err := fondy.Status(&models.InvoiceRequest{InvoiceID: id, Merchant: merchant})
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, nil
+	}
+
+	if order.ResponseStatus != nil && *order.ResponseStatus != consts.FondyResponseStatusSuccess {
+		if order.ErrorCode != nil && fondy_status.StatusCode(*order.ErrorCode) == fondy_status.OrderNotFound {
+			return nil, nil
+		}
+
+		return nil, (&models.StatusResponse{Response: *order}).Error()
+	}
+
+	if order.OrderStatus == nil {
+		return nil, nil
+	}
+
+	return order, nil
+}
+
+// compare adds differences between ledger and Fondy order, returns true when any was found
+func (r *Report) compare(e *expected, order *models.Order) bool {
+	before := len(r.Differences)
+	totals := order.Totals()
+
+	actualAmount := totals.Captured
+	if actualAmount == 0 {
+		actualAmount = totals.Amount
+	}
+
+	if e.amount() != actualAmount {
+		r.add(Difference{Kind: KindAmountMismatch, OrderID: e.orderID, Field: "amount", Expected: minor(e.amount()), Actual: minor(actualAmount)})
+	}
+
+	if e.currency != "" && order.Currency != nil && e.currency != string(*order.Currency) {
+		r.add(Difference{Kind: KindCurrencyMismatch, OrderID: e.orderID, Field: "currency", Expected: e.currency, Actual: string(*order.Currency)})
+	}
+
+	if e.status != "" && (order.OrderStatus == nil || e.status != string(*order.OrderStatus)) {
+		var actualStatus string
+		if order.OrderStatus != nil {
+			actualStatus = string(*order.OrderStatus)
+		}
+
+		r.add(Difference{Kind: KindStatusMismatch, OrderID: e.orderID, Field: "order_status", Expected: e.status, Actual: actualStatus})
+	}
+
+	switch {
+	case totals.Reversed > 0 && e.refunded == 0 && e.charged > 0:
+		r.add(Difference{Kind: KindUnexpectedReversal, OrderID: e.orderID, Field: "reversal_amount", Expected: minor(0), Actual: minor(totals.Reversed)})
+	case e.refunded != totals.Reversed && (e.charged > 0 || e.refunded > 0):
+		r.add(Difference{Kind: KindAmountMismatch, OrderID: e.orderID, Field: "reversal_amount", Expected: minor(e.refunded), Actual: minor(totals.Reversed)})
+	}
+
+	settled := int64(math.Round(order.SplitedAmount() * 100))
+	if e.settled != 0 && e.settled != settled {
+		r.add(Difference{Kind: KindSettlementMismatch, OrderID: e.orderID, Field: "settlement_amount", Expected: minor(e.settled), Actual: minor(settled)})
+	}
+
+	return len(r.Differences) > before
+}
+
+func minor(amount int64) string {
+	return strconv.FormatInt(amount, 10)
+}
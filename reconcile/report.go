@@ -0,0 +1,117 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package reconcile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+)
+
+type DifferenceKind string
+
+const (
+	KindMissingAtFondy     DifferenceKind = "missing_at_fondy"
+	KindMissingLocally     DifferenceKind = "missing_locally"
+	KindAmountMismatch     DifferenceKind = "amount_mismatch"
+	KindCurrencyMismatch   DifferenceKind = "currency_mismatch"
+	KindStatusMismatch     DifferenceKind = "status_mismatch"
+	KindUnexpectedReversal DifferenceKind = "unexpected_reversal"
+	KindSettlementMismatch DifferenceKind = "settlement_mismatch"
+	KindStatusCheckFailed  DifferenceKind = "status_check_failed" // Actual holds the Status fallback error
+)
+
+// Difference single discrepancy, amounts are in minor units
+type Difference struct {
+	Kind     DifferenceKind `json:"kind"`
+	OrderID  string         `json:"order_id"`
+	Field    string         `json:"field"`
+	Expected string         `json:"expected"`
+	Actual   string         `json:"actual"`
+}
+
+type Report struct {
+	From        time.Time    `json:"from"`
+	To          time.Time    `json:"to"`
+	Matched     int          `json:"matched"`
+	Differences []Difference `json:"differences"`
+}
+
+func (r *Report) add(difference Difference) {
+	r.Differences = append(r.Differences, difference)
+}
+
+func (r *Report) sort() {
+	sort.SliceStable(r.Differences, func(i, j int) bool {
+		if r.Differences[i].OrderID != r.Differences[j].OrderID {
+			return r.Differences[i].OrderID < r.Differences[j].OrderID
+		}
+
+		return r.Differences[i].Kind < r.Differences[j].Kind
+	})
+}
+
+// ByKind returns differences of given kind
+func (r *Report) ByKind(kind DifferenceKind) []Difference {
+	var differences []Difference
+
+	for _, difference := range r.Differences {
+		if difference.Kind == kind {
+			differences = append(differences, difference)
+		}
+	}
+
+	return differences
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes differences with header row
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"kind", "order_id", "field", "expected", "actual"})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range r.Differences {
+		err = writer.Write([]string{string(d.Kind), d.OrderID, d.Field, d.Expected, d.Actual})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,469 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package file_recorder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stremovskyy/gofondy/recorder"
+)
+
+const (
+	KindRequest  = "request"
+	KindResponse = "response"
+	KindError    = "error"
+	KindMetrics  = "metrics"
+
+	fileTimeFormat = "20060102T150405.000000000"
+)
+
+type JSONLinesOptions struct {
+	Debug bool
+	// Dir where files are written, it is created when missing
+	Dir    string
+	Prefix string
+	// MaxSize rotates file once it grows over this many bytes, zero disables size rotation
+	MaxSize int64
+	// MaxAge rotates file once it is open longer, zero disables age rotation
+	MaxAge time.Duration
+	// Retention deletes rotated files older than this, zero keeps them forever
+	Retention time.Duration
+}
+
+func NewDefaultJSONLinesOptions(dir string) *JSONLinesOptions {
+	return &JSONLinesOptions{
+		Dir:       dir,
+		Prefix:    "fondy",
+		MaxSize:   100 << 20,
+		MaxAge:    24 * time.Hour,
+		Retention: 30 * 24 * time.Hour,
+	}
+}
+
+// Entry one line of recorder file. Body holds UTF-8 payloads as string, other payloads are kept in BodyBase64,
+// both give back exactly the recorded bytes.
+type Entry struct {
+	Time       time.Time         `json:"time"`
+	Kind       string            `json:"kind"`
+	RequestID  string            `json:"request_id"`
+	OrderID    *string           `json:"order_id,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 []byte            `json:"body_base64,omitempty"`
+}
+
+// Payload returns recorded body
+func (e *Entry) Payload() []byte {
+	if e.BodyBase64 != nil {
+		return e.BodyBase64
+	}
+
+	return []byte(e.Body)
+}
+
+type location struct {
+	file   string
+	offset int64
+	length int
+}
+
+type jsonLinesRecorder struct {
+	options *JSONLinesOptions
+	logger  *log.Logger
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	// index of request and response lines by request ID and request IDs by "name:value" tag
+	entries map[string]map[string]location
+	tags    map[string]map[string]string
+}
+
+// NewJSONLinesRecorder creates recorder writing one JSON object per event to rotating files.
+// Index of existing files is rebuilt on start, so records survive restarts. Returned client implements io.Closer.
+func NewJSONLinesRecorder(options *JSONLinesOptions) (recorder.Client, error) {
+	if options == nil || options.Dir == "" {
+		return nil, errors.New("json lines recorder: dir is required")
+	}
+
+	err := os.MkdirAll(options.Dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &jsonLinesRecorder{
+		options: options,
+		logger:  log.New(log.Writer(), "[JSON Lines Recorder]: ", log.LstdFlags),
+		entries: map[string]map[string]location{KindRequest: {}, KindResponse: {}},
+		tags:    map[string]map[string]string{},
+	}
+
+	err = r.cleanup()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.rebuildIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *jsonLinesRecorder) RecordRequest(ctx context.Context, orderID *string, requestID string, request []byte, tags map[string]string) error {
+	return r.write(KindRequest, orderID, requestID, request, tags)
+}
+
+func (r *jsonLinesRecorder) RecordResponse(ctx context.Context, orderID *string, requestID string, response []byte, tags map[string]string) error {
+	return r.write(KindResponse, orderID, requestID, response, tags)
+}
+
+func (r *jsonLinesRecorder) RecordError(ctx context.Context, orderID *string, requestID string, err error, tags map[string]string) error {
+	return r.write(KindError, orderID, requestID, []byte(err.Error()), tags)
+}
+
+func (r *jsonLinesRecorder) RecordMetrics(ctx context.Context, orderID *string, requestID string, metrics map[string]string, tags map[string]string) error {
+	body, err := json.Marshal(metrics)
+	if err != nil {
+		return fmt.Errorf("cannot marshal metrics: %w", err)
+	}
+
+	return r.write(KindMetrics, orderID, requestID, body, tags)
+}
+
+func (r *jsonLinesRecorder) GetRequest(ctx context.Context, requestID string) ([]byte, error) {
+	return r.read(KindRequest, requestID)
+}
+
+func (r *jsonLinesRecorder) GetResponse(ctx context.Context, requestID string) ([]byte, error) {
+	return r.read(KindResponse, requestID)
+}
+
+// FindByTag returns request IDs of records tagged with "name:value"
+func (r *jsonLinesRecorder) FindByTag(ctx context.Context, tag string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.tags[tag]))
+	for id := range r.tags[tag] {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func (r *jsonLinesRecorder) write(kind string, orderID *string, requestID string, payload []byte, tags map[string]string) error {
+	entry := Entry{
+		Time:      time.Now().UTC(),
+		Kind:      kind,
+		RequestID: requestID,
+		OrderID:   orderID,
+		Tags:      tags,
+	}
+
+	if utf8.Valid(payload) {
+		entry.Body = string(payload)
+	} else {
+		entry.BodyBase64 = payload
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err = r.rotate(int64(len(line)))
+	if err != nil {
+		return err
+	}
+
+	offset := r.size
+
+	_, err = r.file.Write(line)
+	if err != nil {
+		return err
+	}
+
+	r.size += int64(len(line))
+	r.index(&entry, location{file: r.file.Name(), offset: offset, length: len(line)})
+
+	if r.options.Debug {
+		r.logger.Printf("%s %s written to %s", kind, requestID, r.file.Name())
+	}
+
+	return nil
+}
+
+func (r *jsonLinesRecorder) read(kind string, requestID string) ([]byte, error) {
+	r.mu.Lock()
+	loc, ok := r.entries[kind][requestID]
+	r.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%s %s not found", kind, requestID)
+	}
+
+	f, err := os.Open(loc.file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	line := make([]byte, loc.length)
+
+	_, err = f.ReadAt(line, loc.offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	err = json.Unmarshal(line, &entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.Payload(), nil
+}
+
+func (r *jsonLinesRecorder) index(entry *Entry, loc location) {
+	if entries, ok := r.entries[entry.Kind]; ok {
+		entries[entry.RequestID] = loc
+	}
+
+	r.tag("request_id:"+entry.RequestID, entry.RequestID, loc.file)
+
+	for name, value := range entry.Tags {
+		r.tag(name+":"+value, entry.RequestID, loc.file)
+	}
+}
+
+// tag indexes request ID under tag, remembering the latest file it was seen in
+func (r *jsonLinesRecorder) tag(tag string, requestID string, file string) {
+	ids, ok := r.tags[tag]
+	if !ok {
+		ids = map[string]string{}
+		r.tags[tag] = ids
+	}
+
+	ids[requestID] = file
+}
+
+// rotate opens new file when there is none or current one is too big or too old for next line
+func (r *jsonLinesRecorder) rotate(next int64) error {
+	if r.file != nil {
+		tooBig := r.options.MaxSize > 0 && r.size > 0 && r.size+next > r.options.MaxSize
+		tooOld := r.options.MaxAge > 0 && time.Since(r.openedAt) > r.options.MaxAge
+
+		if !tooBig && !tooOld {
+			return nil
+		}
+
+		err := r.file.Close()
+		if err != nil {
+			return err
+		}
+
+		r.file = nil
+
+		err = r.cleanup()
+		if err != nil {
+			r.logger.Printf("[ERROR] retention cleanup failed: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	name := filepath.Join(r.options.Dir, fmt.Sprintf("%s-%s.jsonl", r.options.Prefix, now.Format(fileTimeFormat)))
+
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	r.file, r.size, r.openedAt = f, info.Size(), now
+
+	return nil
+}
+
+// files returns recorder files ordered from oldest to newest
+func (r *jsonLinesRecorder) files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(r.options.Dir, r.options.Prefix+"-*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// cleanup deletes files past retention and drops their index entries
+func (r *jsonLinesRecorder) cleanup() error {
+	if r.options.Retention <= 0 {
+		return nil
+	}
+
+	files, err := r.files()
+	if err != nil {
+		return err
+	}
+
+	deleted := map[string]bool{}
+
+	for _, name := range files {
+		if r.file != nil && name == r.file.Name() {
+			continue
+		}
+
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+
+		if time.Since(info.ModTime()) > r.options.Retention {
+			err = os.Remove(name)
+			if err != nil {
+				return err
+			}
+
+			deleted[name] = true
+		}
+	}
+
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	for _, entries := range r.entries {
+		for id, loc := range entries {
+			if deleted[loc.file] {
+				delete(entries, id)
+			}
+		}
+	}
+
+	for tag, ids := range r.tags {
+		for id, file := range ids {
+			if deleted[file] {
+				delete(ids, id)
+			}
+		}
+
+		if len(ids) == 0 {
+			delete(r.tags, tag)
+		}
+	}
+
+	return nil
+}
+
+func (r *jsonLinesRecorder) rebuildIndex() error {
+	files, err := r.files()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		err = r.indexFile(name)
+		if err != nil {
+			return fmt.Errorf("cannot index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *jsonLinesRecorder) indexFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// partially written last line is skipped
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var entry Entry
+		if json.Unmarshal(line, &entry) == nil && strings.TrimSpace(entry.RequestID) != "" {
+			r.index(&entry, location{file: name, offset: offset, length: len(line)})
+		}
+
+		offset += int64(len(line))
+	}
+}
+
+// Close closes current file
+func (r *jsonLinesRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
@@ -0,0 +1,275 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package file_recorder
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/gofondy/recorder"
+)
+
+func newTestRecorder(t *testing.T, options *JSONLinesOptions) recorder.Client {
+	t.Helper()
+
+	r, err := NewJSONLinesRecorder(options)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+
+	t.Cleanup(func() { _ = r.(io.Closer).Close() })
+
+	return r
+}
+
+func recorderFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "fondy-*.jsonl"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+
+	return files
+}
+
+func TestPayloadIsReturnedUnchanged(t *testing.T) {
+	r := newTestRecorder(t, NewDefaultJSONLinesOptions(t.TempDir()))
+	ctx := context.Background()
+
+	payloads := map[string][]byte{
+		"json":   []byte("{\"checkout_url\": \"https://pay.fondy.eu/merchants/x/default/index.html?token=a&lang=uk\",\n \"html\": \"<b>\"}"),
+		"text":   []byte("order_id=1&amount=100"),
+		"binary": {0xff, 0xfe, 0x00, 0x01},
+		"empty":  {},
+	}
+
+	for id, payload := range payloads {
+		err := r.RecordRequest(ctx, nil, id, payload, nil)
+		if err != nil {
+			t.Fatalf("record %s: %v", id, err)
+		}
+
+		err = r.RecordResponse(ctx, nil, id, payload, nil)
+		if err != nil {
+			t.Fatalf("record %s: %v", id, err)
+		}
+	}
+
+	for id, payload := range payloads {
+		request, err := r.GetRequest(ctx, id)
+		if err != nil || string(request) != string(payload) {
+			t.Errorf("GetRequest(%s) = %q, %v, want %q", id, request, err, payload)
+		}
+
+		response, err := r.GetResponse(ctx, id)
+		if err != nil || string(response) != string(payload) {
+			t.Errorf("GetResponse(%s) = %q, %v, want %q", id, response, err, payload)
+		}
+	}
+}
+
+func TestIndexIsRebuiltAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	first, err := NewJSONLinesRecorder(NewDefaultJSONLinesOptions(dir))
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+
+	err = first.RecordRequest(ctx, nil, "req-1", []byte(`{"a":1}`), map[string]string{"merchant": "42"})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = first.(io.Closer).Close()
+	if err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	second := newTestRecorder(t, NewDefaultJSONLinesOptions(dir))
+
+	request, err := second.GetRequest(ctx, "req-1")
+	if err != nil || string(request) != `{"a":1}` {
+		t.Errorf("GetRequest after restart = %q, %v", request, err)
+	}
+
+	ids, err := second.FindByTag(ctx, "merchant:42")
+	if err != nil || len(ids) != 1 || ids[0] != "req-1" {
+		t.Errorf("FindByTag after restart = %v, %v", ids, err)
+	}
+}
+
+func TestFilesAreRotatedBySize(t *testing.T) {
+	dir := t.TempDir()
+	options := NewDefaultJSONLinesOptions(dir)
+	options.MaxSize = 200
+	r := newTestRecorder(t, options)
+	ctx := context.Background()
+
+	for _, id := range []string{"req-1", "req-2", "req-3"} {
+		err := r.RecordRequest(ctx, nil, id, []byte(`{"payload":"0123456789012345678901234567890123456789"}`), nil)
+		if err != nil {
+			t.Fatalf("record %s: %v", id, err)
+		}
+	}
+
+	if files := recorderFiles(t, dir); len(files) != 3 {
+		t.Errorf("files = %d, want 3", len(files))
+	}
+
+	request, err := r.GetRequest(ctx, "req-1")
+	if err != nil || len(request) == 0 {
+		t.Errorf("GetRequest from rotated file = %q, %v", request, err)
+	}
+}
+
+func TestFilesAreRotatedByAge(t *testing.T) {
+	dir := t.TempDir()
+	options := NewDefaultJSONLinesOptions(dir)
+	options.MaxAge = 10 * time.Millisecond
+	r := newTestRecorder(t, options)
+	ctx := context.Background()
+
+	err := r.RecordRequest(ctx, nil, "req-1", []byte("first"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	err = r.RecordRequest(ctx, nil, "req-2", []byte("second"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	if files := recorderFiles(t, dir); len(files) != 2 {
+		t.Errorf("files = %d, want 2", len(files))
+	}
+}
+
+func TestFilesPastRetentionAreDeleted(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	old, err := NewJSONLinesRecorder(NewDefaultJSONLinesOptions(dir))
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+
+	err = old.RecordRequest(ctx, nil, "old", []byte("old"), map[string]string{"merchant": "42"})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = old.(io.Closer).Close()
+	if err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	expired := time.Now().Add(-48 * time.Hour)
+	for _, name := range recorderFiles(t, dir) {
+		err = os.Chtimes(name, expired, expired)
+		if err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	options := NewDefaultJSONLinesOptions(dir)
+	options.Retention = 24 * time.Hour
+	r := newTestRecorder(t, options)
+
+	if files := recorderFiles(t, dir); len(files) != 0 {
+		t.Errorf("files past retention kept: %v", files)
+	}
+
+	_, err = r.GetRequest(ctx, "old")
+	if err == nil {
+		t.Errorf("record of deleted file still indexed")
+	}
+
+	ids, err := r.FindByTag(ctx, "merchant:42")
+	if err != nil || len(ids) != 0 {
+		t.Errorf("tags of deleted file = %v, %v", ids, err)
+	}
+}
+
+func TestRetentionRunsOnRotation(t *testing.T) {
+	dir := t.TempDir()
+	options := NewDefaultJSONLinesOptions(dir)
+	options.MaxAge = 10 * time.Millisecond
+	options.Retention = time.Hour
+	r := newTestRecorder(t, options)
+	ctx := context.Background()
+
+	err := r.RecordRequest(ctx, nil, "old", []byte("old"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	err = r.RecordRequest(ctx, nil, "rotate", []byte("rotate"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	files := recorderFiles(t, dir)
+	if len(files) != 2 {
+		t.Fatalf("files = %d, want 2", len(files))
+	}
+
+	expired := time.Now().Add(-2 * time.Hour)
+	err = os.Chtimes(files[0], expired, expired)
+	if err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	err = r.RecordRequest(ctx, nil, "new", []byte("new"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	if _, err = os.Stat(files[0]); !os.IsNotExist(err) {
+		t.Errorf("file past retention kept after rotation: %v", err)
+	}
+
+	_, err = r.GetRequest(ctx, "old")
+	if err == nil {
+		t.Errorf("record of deleted file still indexed")
+	}
+
+	request, err := r.GetRequest(ctx, "rotate")
+	if err != nil || string(request) != "rotate" {
+		t.Errorf("GetRequest of kept file = %q, %v", request, err)
+	}
+}
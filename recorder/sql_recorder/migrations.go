@@ -0,0 +1,127 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package sql_recorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	RequestsTable  = "requests"
+	ResponsesTable = "responses"
+	ErrorsTable    = "errors"
+	MetricsTable   = "metrics"
+	TagsTable      = "tags"
+	versionsTable  = "schema_migrations"
+)
+
+// column sizes of the schema, records exceeding them are rejected before buffering
+const (
+	maxIDLength       = 64
+	maxTagNameLength  = 128
+	maxTagValueLength = 255
+)
+
+// migrations are applied in order and never changed once released, {prefix}, {blob} and {timestamp} are substituted
+var migrations = []string{
+	`CREATE TABLE {prefix}requests (request_id VARCHAR(64) NOT NULL, order_id VARCHAR(64), payload {blob} NOT NULL, created_at {timestamp} NOT NULL);
+CREATE TABLE {prefix}responses (request_id VARCHAR(64) NOT NULL, order_id VARCHAR(64), payload {blob} NOT NULL, created_at {timestamp} NOT NULL);
+CREATE TABLE {prefix}errors (request_id VARCHAR(64) NOT NULL, order_id VARCHAR(64), payload {blob} NOT NULL, created_at {timestamp} NOT NULL);
+CREATE TABLE {prefix}metrics (request_id VARCHAR(64) NOT NULL, order_id VARCHAR(64), payload {blob} NOT NULL, created_at {timestamp} NOT NULL);
+CREATE TABLE {prefix}tags (request_id VARCHAR(64) NOT NULL, kind VARCHAR(16) NOT NULL, name VARCHAR(128) NOT NULL, value VARCHAR(255) NOT NULL, created_at {timestamp} NOT NULL)`,
+	`CREATE INDEX {prefix}tags_name_value ON {prefix}tags (name, value);
+CREATE INDEX {prefix}requests_request_id ON {prefix}requests (request_id);
+CREATE INDEX {prefix}responses_request_id ON {prefix}responses (request_id);
+CREATE INDEX {prefix}errors_request_id ON {prefix}errors (request_id);
+CREATE INDEX {prefix}metrics_request_id ON {prefix}metrics (request_id);
+CREATE INDEX {prefix}requests_order_id ON {prefix}requests (order_id);
+CREATE INDEX {prefix}responses_order_id ON {prefix}responses (order_id)`,
+}
+
+// Migrate creates or upgrades recorder schema, applied versions are kept in schema_migrations table
+func (r *Recorder) Migrate(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (version INTEGER NOT NULL PRIMARY KEY, applied_at %s NOT NULL)",
+		r.table(versionsTable), r.options.Dialect.TimestampType,
+	))
+	if err != nil {
+		return fmt.Errorf("cannot create migrations table: %w", err)
+	}
+
+	var current int
+	err = r.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+r.table(versionsTable)).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("cannot read schema version: %w", err)
+	}
+
+	for i := current; i < len(migrations); i++ {
+		err = r.migrate(ctx, i+1, migrations[i])
+		if err != nil {
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) migrate(ctx context.Context, version int, migration string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(tx)
+
+	migration = strings.NewReplacer(
+		"{prefix}", r.options.TablePrefix,
+		"{blob}", r.options.Dialect.BlobType,
+		"{timestamp}", r.options.Dialect.TimestampType,
+	).Replace(migration)
+
+	for _, statement := range strings.Split(migration, ";\n") {
+		_, err = tx.ExecContext(ctx, statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (version, applied_at) VALUES (%s, %s)", r.table(versionsTable), r.placeholder(1), r.placeholder(2)),
+		version, time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func rollback(tx *sql.Tx) {
+	_ = tx.Rollback()
+}
@@ -0,0 +1,71 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package sql_recorder
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// Dialect SQL differences between supported databases
+type Dialect struct {
+	Name          string
+	BlobType      string
+	TimestampType string
+	// Placeholder returns bind parameter for n-th (1 based) argument
+	Placeholder func(n int) string
+}
+
+var (
+	Postgres = Dialect{Name: "postgres", BlobType: "BYTEA", TimestampType: "TIMESTAMPTZ", Placeholder: func(n int) string { return "$" + strconv.Itoa(n) }}
+	SQLite   = Dialect{Name: "sqlite", BlobType: "BLOB", TimestampType: "TIMESTAMP", Placeholder: func(int) string { return "?" }}
+	MySQL    = Dialect{Name: "mysql", BlobType: "LONGBLOB", TimestampType: "DATETIME(6)", Placeholder: func(int) string { return "?" }}
+)
+
+type Options struct {
+	Debug   bool
+	DB      *sql.DB
+	Dialect Dialect
+	// TablePrefix prepended to every table name
+	TablePrefix string
+	// BatchSize records buffered before they are written in one insert
+	BatchSize int
+	// FlushInterval how often buffered records are written even if batch is not full
+	FlushInterval time.Duration
+	// MaxBuffer records kept in memory while database is unavailable, oldest are dropped above it, 0 means unlimited
+	MaxBuffer int
+}
+
+func NewDefaultOptions(db *sql.DB, dialect Dialect) *Options {
+	return &Options{
+		DB:            db,
+		Dialect:       dialect,
+		TablePrefix:   "fondy_",
+		BatchSize:     100,
+		FlushInterval: time.Second,
+		MaxBuffer:     10000,
+	}
+}
@@ -0,0 +1,408 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package sql_recorder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stremovskyy/gofondy/recorder"
+)
+
+// ErrRecordsDropped returned by Flush when failed write overflows MaxBuffer
+var ErrRecordsDropped = errors.New("sql recorder: buffer overflow, records dropped")
+
+type record struct {
+	table     string
+	requestID string
+	orderID   *string
+	payload   []byte
+	tags      map[string]string
+	createdAt time.Time
+}
+
+// Recorder recorder.Client on database/sql, records are buffered and written in batches
+type Recorder struct {
+	db      *sql.DB
+	options *Options
+	logger  *log.Logger
+
+	mu     sync.Mutex
+	buffer []record
+	// failing is set while flushes fail, records are then written by background flush only
+	failing bool
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+var _ recorder.Client = (*Recorder)(nil)
+
+// NewSQLRecorder creates recorder and migrates its schema
+func NewSQLRecorder(options *Options) (*Recorder, error) {
+	if options == nil || options.DB == nil {
+		return nil, errors.New("sql recorder: database is required")
+	}
+
+	if options.Dialect.Placeholder == nil {
+		return nil, errors.New("sql recorder: dialect is required")
+	}
+
+	if options.BatchSize < 1 {
+		options.BatchSize = 1
+	}
+
+	r := &Recorder{
+		db:      options.DB,
+		options: options,
+		logger:  log.New(log.Writer(), "[SQL Recorder]: ", log.LstdFlags),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	err := r.Migrate(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	go r.flushLoop()
+
+	return r, nil
+}
+
+func (r *Recorder) RecordRequest(ctx context.Context, orderID *string, requestID string, request []byte, tags map[string]string) error {
+	return r.add(ctx, RequestsTable, orderID, requestID, request, tags)
+}
+
+func (r *Recorder) RecordResponse(ctx context.Context, orderID *string, requestID string, response []byte, tags map[string]string) error {
+	return r.add(ctx, ResponsesTable, orderID, requestID, response, tags)
+}
+
+func (r *Recorder) RecordError(ctx context.Context, orderID *string, requestID string, err error, tags map[string]string) error {
+	return r.add(ctx, ErrorsTable, orderID, requestID, []byte(err.Error()), tags)
+}
+
+func (r *Recorder) RecordMetrics(ctx context.Context, orderID *string, requestID string, metrics map[string]string, tags map[string]string) error {
+	jsonData, err := json.Marshal(metrics)
+	if err != nil {
+		return fmt.Errorf("cannot marshal metrics: %w", err)
+	}
+
+	return r.add(ctx, MetricsTable, orderID, requestID, jsonData, tags)
+}
+
+func (r *Recorder) GetRequest(ctx context.Context, requestID string) ([]byte, error) {
+	return r.get(ctx, RequestsTable, requestID)
+}
+
+func (r *Recorder) GetResponse(ctx context.Context, requestID string) ([]byte, error) {
+	return r.get(ctx, ResponsesTable, requestID)
+}
+
+// FindByTag returns request IDs of records tagged with "name:value"
+func (r *Recorder) FindByTag(ctx context.Context, tag string) ([]string, error) {
+	parts := strings.SplitN(tag, ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("tag must be in name:value form: %s", tag)
+	}
+
+	r.flushBeforeRead(ctx)
+
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT DISTINCT request_id FROM %s WHERE name = %s AND value = %s", r.table(TagsTable), r.placeholder(1), r.placeholder(2)),
+		parts[0], parts[1],
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Purge deletes records older than given time, e.g. after retention period
+func (r *Recorder) Purge(ctx context.Context, before time.Time) error {
+	for _, table := range []string{RequestsTable, ResponsesTable, ErrorsTable, MetricsTable, TagsTable} {
+		_, err := r.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE created_at < %s", r.table(table), r.placeholder(1)), before.UTC())
+		if err != nil {
+			return fmt.Errorf("cannot purge %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Close stops background flushing and writes buffered records
+func (r *Recorder) Close(ctx context.Context) error {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+
+	<-r.stopped
+
+	return r.Flush(ctx)
+}
+
+func (r *Recorder) add(ctx context.Context, table string, orderID *string, requestID string, payload []byte, tags map[string]string) error {
+	err := validate(orderID, requestID, tags)
+	if err != nil {
+		return err
+	}
+
+	if payload == nil {
+		payload = []byte{}
+	}
+
+	r.mu.Lock()
+	r.buffer = append(r.buffer, record{
+		table:     table,
+		requestID: requestID,
+		orderID:   orderID,
+		payload:   payload,
+		tags:      tags,
+		createdAt: time.Now().UTC(),
+	})
+	full := len(r.buffer) >= r.options.BatchSize && !r.failing
+	r.mu.Unlock()
+
+	if full {
+		return r.Flush(ctx)
+	}
+
+	return nil
+}
+
+func (r *Recorder) get(ctx context.Context, table string, requestID string) ([]byte, error) {
+	r.flushBeforeRead(ctx)
+
+	var payload []byte
+	err := r.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT payload FROM %s WHERE request_id = %s ORDER BY created_at DESC LIMIT 1", r.table(table), r.placeholder(1)),
+		requestID,
+	).Scan(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// flushBeforeRead writes buffered records so reads see them, record may be in database already so failure is only logged
+func (r *Recorder) flushBeforeRead(ctx context.Context) {
+	err := r.Flush(ctx)
+	if err != nil {
+		r.logger.Printf("[ERROR] cannot flush records before read: %v", err)
+	}
+}
+
+func (r *Recorder) flushLoop() {
+	defer close(r.stopped)
+
+	if r.options.FlushInterval <= 0 {
+		<-r.stop
+		return
+	}
+
+	ticker := time.NewTicker(r.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			err := r.Flush(context.Background())
+			if err != nil {
+				r.logger.Printf("[ERROR] cannot flush records: %v", err)
+			}
+		}
+	}
+}
+
+// Flush writes buffered records in one transaction, records are kept in buffer when write fails
+// up to MaxBuffer, the oldest ones above it are dropped
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	batch := r.buffer
+	r.buffer = nil
+	r.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := r.write(ctx, batch)
+	if err != nil {
+		r.mu.Lock()
+		r.failing = true
+		r.buffer = append(batch, r.buffer...)
+		dropped := 0
+		if r.options.MaxBuffer > 0 && len(r.buffer) > r.options.MaxBuffer {
+			dropped = len(r.buffer) - r.options.MaxBuffer
+			r.buffer = append([]record(nil), r.buffer[dropped:]...)
+		}
+		r.mu.Unlock()
+
+		if dropped > 0 {
+			return fmt.Errorf("%w: %d: %v", ErrRecordsDropped, dropped, err)
+		}
+
+		return err
+	}
+
+	r.mu.Lock()
+	r.failing = false
+	r.mu.Unlock()
+
+	if r.options.Debug {
+		r.logger.Printf("flushed %d records", len(batch))
+	}
+
+	return nil
+}
+
+func (r *Recorder) write(ctx context.Context, batch []record) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer rollback(tx)
+
+	byTable := make(map[string][]record)
+	var tags [][]interface{}
+
+	for _, rec := range batch {
+		byTable[rec.table] = append(byTable[rec.table], rec)
+
+		for name, value := range rec.tags {
+			tags = append(tags, []interface{}{rec.requestID, rec.table, name, value, rec.createdAt})
+		}
+
+		if _, ok := rec.tags["request_id"]; !ok {
+			tags = append(tags, []interface{}{rec.requestID, rec.table, "request_id", rec.requestID, rec.createdAt})
+		}
+	}
+
+	for table, records := range byTable {
+		rows := make([][]interface{}, 0, len(records))
+		for _, rec := range records {
+			rows = append(rows, []interface{}{rec.requestID, rec.orderID, rec.payload, rec.createdAt})
+		}
+
+		err = r.insert(ctx, tx, table, []string{"request_id", "order_id", "payload", "created_at"}, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.insert(ctx, tx, TagsTable, []string{"request_id", "kind", "name", "value", "created_at"}, tags)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insert writes rows with multi row INSERT statements, at most BatchSize rows each
+func (r *Recorder) insert(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]interface{}) error {
+	for start := 0; start < len(rows); start += r.options.BatchSize {
+		end := start + r.options.BatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		values := make([]string, 0, end-start)
+		args := make([]interface{}, 0, (end-start)*len(columns))
+
+		for _, row := range rows[start:end] {
+			placeholders := make([]string, len(row))
+			for i := range row {
+				placeholders[i] = r.placeholder(len(args) + i + 1)
+			}
+
+			values = append(values, "("+strings.Join(placeholders, ", ")+")")
+			args = append(args, row...)
+		}
+
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", r.table(table), strings.Join(columns, ", "), strings.Join(values, ", "))
+
+		_, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("cannot insert into %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// validate rejects records that do not fit schema columns, such record would fail every batch it is written in
+func validate(orderID *string, requestID string, tags map[string]string) error {
+	if requestID == "" || utf8.RuneCountInString(requestID) > maxIDLength {
+		return fmt.Errorf("sql recorder: request id must be 1 to %d characters: %q", maxIDLength, requestID)
+	}
+
+	if orderID != nil && utf8.RuneCountInString(*orderID) > maxIDLength {
+		return fmt.Errorf("sql recorder: order id is longer than %d characters: %q", maxIDLength, *orderID)
+	}
+
+	for name, value := range tags {
+		if utf8.RuneCountInString(name) > maxTagNameLength || utf8.RuneCountInString(value) > maxTagValueLength {
+			return fmt.Errorf("sql recorder: tag %q is longer than %d:%d characters", name, maxTagNameLength, maxTagValueLength)
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) table(name string) string {
+	return r.options.TablePrefix + name
+}
+
+func (r *Recorder) placeholder(n int) string {
+	return r.options.Dialect.Placeholder(n)
+}
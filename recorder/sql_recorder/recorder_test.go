@@ -0,0 +1,358 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package sql_recorder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestRecorder(t *testing.T, batchSize int) (*Recorder, *sql.DB) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	// every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	options := NewDefaultOptions(db, SQLite)
+	options.BatchSize = batchSize
+	options.FlushInterval = 0
+
+	r, err := NewSQLRecorder(options)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+
+	t.Cleanup(func() { _ = r.Close(context.Background()) })
+
+	return r, db
+}
+
+func count(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM fondy_" + table).Scan(&n)
+	if err != nil {
+		t.Fatalf("count %s: %v", table, err)
+	}
+
+	return n
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	r, db := newTestRecorder(t, 10)
+	ctx := context.Background()
+
+	err := r.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("second migrate: %v", err)
+	}
+
+	if n := count(t, db, versionsTable); n != len(migrations) {
+		t.Errorf("schema versions = %d, want %d", n, len(migrations))
+	}
+}
+
+func TestRecordsAreBufferedUntilBatchIsFull(t *testing.T) {
+	r, db := newTestRecorder(t, 3)
+	ctx := context.Background()
+	orderID := "order-1"
+
+	for _, id := range []string{"req-1", "req-2"} {
+		err := r.RecordRequest(ctx, &orderID, id, []byte(id), nil)
+		if err != nil {
+			t.Fatalf("record %s: %v", id, err)
+		}
+	}
+
+	if n := count(t, db, RequestsTable); n != 0 {
+		t.Fatalf("requests written before batch is full: %d", n)
+	}
+
+	err := r.RecordResponse(ctx, &orderID, "req-1", []byte("resp-1"), nil)
+	if err != nil {
+		t.Fatalf("record response: %v", err)
+	}
+
+	if n := count(t, db, RequestsTable); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+
+	if n := count(t, db, ResponsesTable); n != 1 {
+		t.Errorf("responses = %d, want 1", n)
+	}
+}
+
+func TestGetAndFindByTagFlushBuffer(t *testing.T) {
+	r, _ := newTestRecorder(t, 100)
+	ctx := context.Background()
+
+	err := r.RecordRequest(ctx, nil, "req-1", []byte("request"), map[string]string{"merchant": "42"})
+	if err != nil {
+		t.Fatalf("record request: %v", err)
+	}
+
+	err = r.RecordResponse(ctx, nil, "req-1", []byte("response"), map[string]string{"merchant": "42"})
+	if err != nil {
+		t.Fatalf("record response: %v", err)
+	}
+
+	err = r.RecordRequest(ctx, nil, "req-2", []byte("other"), map[string]string{"merchant": "7"})
+	if err != nil {
+		t.Fatalf("record request: %v", err)
+	}
+
+	request, err := r.GetRequest(ctx, "req-1")
+	if err != nil || string(request) != "request" {
+		t.Errorf("GetRequest = %q, %v", request, err)
+	}
+
+	response, err := r.GetResponse(ctx, "req-1")
+	if err != nil || string(response) != "response" {
+		t.Errorf("GetResponse = %q, %v", response, err)
+	}
+
+	_, err = r.GetResponse(ctx, "req-2")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetResponse of missing record err = %v, want sql.ErrNoRows", err)
+	}
+
+	ids, err := r.FindByTag(ctx, "merchant:42")
+	if err != nil || len(ids) != 1 || ids[0] != "req-1" {
+		t.Errorf("FindByTag(merchant:42) = %v, %v", ids, err)
+	}
+
+	ids, err = r.FindByTag(ctx, "request_id:req-2")
+	if err != nil || len(ids) != 1 || ids[0] != "req-2" {
+		t.Errorf("FindByTag(request_id:req-2) = %v, %v", ids, err)
+	}
+
+	_, err = r.FindByTag(ctx, "merchant")
+	if err == nil {
+		t.Errorf("FindByTag accepted tag without value")
+	}
+}
+
+func TestPurgeDeletesOldRecords(t *testing.T) {
+	r, db := newTestRecorder(t, 100)
+	ctx := context.Background()
+
+	err := r.RecordRequest(ctx, nil, "old", []byte("old"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = r.Flush(ctx)
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	err = r.RecordRequest(ctx, nil, "new", []byte("new"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = r.Flush(ctx)
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	err = r.Purge(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	rows, err := db.Query("SELECT request_id FROM fondy_requests")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) != 1 || ids[0] != "new" {
+		t.Errorf("requests after purge = %v, want [new]", ids)
+	}
+
+	tags, err := r.FindByTag(ctx, "request_id:old")
+	if err != nil || len(tags) != 0 {
+		t.Errorf("tags of purged record = %v, %v", tags, err)
+	}
+}
+
+func TestFailedFlushKeepsAtMostMaxBuffer(t *testing.T) {
+	r, db := newTestRecorder(t, 100)
+	r.options.MaxBuffer = 2
+	ctx := context.Background()
+
+	_, err := db.Exec("DROP TABLE fondy_requests")
+	if err != nil {
+		t.Fatalf("drop: %v", err)
+	}
+
+	for _, id := range []string{"req-1", "req-2"} {
+		err = r.RecordRequest(ctx, nil, id, []byte(id), nil)
+		if err != nil {
+			t.Fatalf("record %s: %v", id, err)
+		}
+	}
+
+	err = r.Flush(ctx)
+	if err == nil || errors.Is(err, ErrRecordsDropped) {
+		t.Fatalf("flush within MaxBuffer err = %v, want write error", err)
+	}
+
+	err = r.RecordRequest(ctx, nil, "req-3", []byte("req-3"), nil)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = r.Flush(ctx)
+	if !errors.Is(err, ErrRecordsDropped) {
+		t.Fatalf("flush above MaxBuffer err = %v, want ErrRecordsDropped", err)
+	}
+
+	var kept []string
+	for _, rec := range r.buffer {
+		kept = append(kept, rec.requestID)
+	}
+	sort.Strings(kept)
+
+	if len(kept) != 2 || kept[0] != "req-2" || kept[1] != "req-3" {
+		t.Errorf("buffer = %v, want newest [req-2 req-3]", kept)
+	}
+}
+
+func TestRecordsNotFittingSchemaAreRejected(t *testing.T) {
+	r, db := newTestRecorder(t, 1)
+	ctx := context.Background()
+	long := strings.Repeat("x", 65)
+
+	err := r.RecordRequest(ctx, &long, "req-1", []byte("request"), nil)
+	if err == nil {
+		t.Errorf("order id longer than column accepted")
+	}
+
+	err = r.RecordRequest(ctx, nil, "req-2", []byte("request"), map[string]string{"card": strings.Repeat("x", 256)})
+	if err == nil {
+		t.Errorf("tag value longer than column accepted")
+	}
+
+	err = r.RecordResponse(ctx, nil, "req-3", nil, nil)
+	if err != nil {
+		t.Fatalf("nil payload: %v", err)
+	}
+
+	if n := count(t, db, ResponsesTable); n != 1 {
+		t.Errorf("responses = %d, want 1", n)
+	}
+
+	if len(r.buffer) != 0 {
+		t.Errorf("rejected records buffered: %d", len(r.buffer))
+	}
+}
+
+func TestFailingFlushIsNotRepeatedOnRecord(t *testing.T) {
+	r, db := newTestRecorder(t, 1)
+	ctx := context.Background()
+
+	_, err := db.Exec("DROP TABLE fondy_requests")
+	if err != nil {
+		t.Fatalf("drop: %v", err)
+	}
+
+	err = r.RecordRequest(ctx, nil, "req-1", []byte("req-1"), nil)
+	if err == nil {
+		t.Fatalf("first failing flush not reported")
+	}
+
+	err = r.RecordRequest(ctx, nil, "req-2", []byte("req-2"), nil)
+	if err != nil {
+		t.Errorf("record flushed inline while database is failing: %v", err)
+	}
+
+	if len(r.buffer) != 2 {
+		t.Errorf("buffer = %d records, want 2", len(r.buffer))
+	}
+}
+
+func TestReadsIgnoreFlushFailure(t *testing.T) {
+	r, db := newTestRecorder(t, 100)
+	ctx := context.Background()
+
+	err := r.RecordRequest(ctx, nil, "req-1", []byte("request"), map[string]string{"merchant": "42"})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	err = r.Flush(ctx)
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	_, err = db.Exec("DROP TABLE fondy_errors")
+	if err != nil {
+		t.Fatalf("drop: %v", err)
+	}
+
+	err = r.RecordError(ctx, nil, "req-1", errors.New("timeout"), nil)
+	if err != nil {
+		t.Fatalf("record error: %v", err)
+	}
+
+	request, err := r.GetRequest(ctx, "req-1")
+	if err != nil || string(request) != "request" {
+		t.Errorf("GetRequest = %q, %v", request, err)
+	}
+
+	ids, err := r.FindByTag(ctx, "merchant:42")
+	if err != nil || len(ids) != 1 {
+		t.Errorf("FindByTag = %v, %v", ids, err)
+	}
+}
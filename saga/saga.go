@@ -0,0 +1,136 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package saga
+
+import (
+	"time"
+
+	"github.com/stremovskyy/gofondy/models/models_v2"
+)
+
+type Step string
+
+const (
+	StepHold                  Step = "hold"
+	StepWaitHold              Step = "wait_hold"
+	StepCapture               Step = "capture"
+	StepWaitCapture           Step = "wait_capture"
+	StepSettle                Step = "settle"
+	StepCompensateSplitRefund Step = "compensate_split_refund"
+	StepCompensateReverse     Step = "compensate_reverse"
+)
+
+type StepStatus string
+
+const (
+	StepStatusDone   StepStatus = "done"
+	StepStatusFailed StepStatus = "failed"
+)
+
+// StepReport outcome of a single saga step
+type StepReport struct {
+	Step        Step       `json:"step"`
+	Status      StepStatus `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	OrderStatus string     `json:"order_status,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  time.Time  `json:"finished_at"`
+}
+
+// Report progress of hold-capture-settle flow, it is what Store persists
+type Report struct {
+	OrderID     string       `json:"order_id"`
+	Steps       []StepReport `json:"steps"`
+	Completed   bool         `json:"completed"`
+	Compensated bool         `json:"compensated"`
+	Failed      bool         `json:"failed"`
+}
+
+func NewReport(orderID string) *Report {
+	return &Report{OrderID: orderID}
+}
+
+// Done reports whether step has already finished successfully, such steps are skipped on resume
+func (r *Report) Done(step Step) bool {
+	for _, s := range r.Steps {
+		if s.Step == step && s.Status == StepStatusDone {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Finished reports whether flow reached its end either way and must not be resumed
+func (r *Report) Finished() bool {
+	return r.Completed || r.Compensated || r.Failed
+}
+
+func (r *Report) Add(step StepReport) {
+	r.Steps = append(r.Steps, step)
+}
+
+// Options of hold-capture-settle flow
+type Options struct {
+	Store Store
+	// PollInterval delay between status checks while waiting for hold and capture
+	PollInterval time.Duration
+	// HoldTimeout how long to wait for hold to be approved (3DS, processing)
+	HoldTimeout time.Duration
+	// CaptureTimeout how long to wait for capture to be confirmed
+	CaptureTimeout time.Duration
+	// CaptureAmount amount to capture in UAH, zero captures whole hold
+	CaptureAmount float64
+	// Receivers explicit settlement receivers, percentage split of merchant split accounts is used when empty
+	Receivers models_v2.Receivers
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Store:          NewMemoryStore(),
+		PollInterval:   2 * time.Second,
+		HoldTimeout:    time.Minute,
+		CaptureTimeout: time.Minute,
+	}
+}
+
+// WithDefaults returns copy of options with zero intervals and timeouts replaced by DefaultOptions values
+func (o Options) WithDefaults() *Options {
+	defaults := DefaultOptions()
+
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaults.PollInterval
+	}
+
+	if o.HoldTimeout <= 0 {
+		o.HoldTimeout = defaults.HoldTimeout
+	}
+
+	if o.CaptureTimeout <= 0 {
+		o.CaptureTimeout = defaults.CaptureTimeout
+	}
+
+	return &o
+}
@@ -0,0 +1,80 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Store persists saga progress so interrupted flow can be resumed
+type Store interface {
+	// Load returns saved report or nil when flow for order has never been started
+	Load(ctx context.Context, orderID string) (*Report, error)
+	Save(ctx context.Context, report *Report) error
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	reports map[string][]byte
+}
+
+// NewMemoryStore creates process local store, progress is lost on restart
+func NewMemoryStore() Store {
+	return &memoryStore{reports: make(map[string][]byte)}
+}
+
+func (s *memoryStore) Load(ctx context.Context, orderID string) (*Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.reports[orderID]
+	if !ok {
+		return nil, nil
+	}
+
+	var report Report
+	err := json.Unmarshal(data, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, report *Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reports[report.OrderID] = data
+
+	return nil
+}
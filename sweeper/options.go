@@ -0,0 +1,134 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package sweeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// Action what sweeper does with hold that reached its age
+type Action string
+
+const (
+	ActionNone    Action = "none"
+	ActionRelease Action = "release"
+	ActionCapture Action = "capture"
+)
+
+// Hold open hold tracked by application
+type Hold struct {
+	Request   *models.InvoiceRequest
+	CreatedAt time.Time
+}
+
+// Source of open holds, implemented by application
+type Source interface {
+	OpenHolds(ctx context.Context) ([]Hold, error)
+	// Resolve is called when hold is not open anymore: it was captured or released by sweeper (action)
+	// or finished outside of it (ActionNone)
+	Resolve(ctx context.Context, hold Hold, order *models.Order, action Action) error
+}
+
+// Gateway operations sweeper needs, gofondy V1 implements it
+type Gateway interface {
+	Status(invoiceRequest *models.InvoiceRequest) (*models.Order, error)
+	Capture(invoiceRequest *models.InvoiceRequest) (*models.Order, error)
+	CancelHold(invoiceRequest *models.InvoiceRequest) (*models.HoldCancelResult, error)
+}
+
+// Policy chooses action for hold that reached Options.MaxAge
+type Policy func(hold Hold, order *models.Order) Action
+
+func ReleasePolicy(Hold, *models.Order) Action { return ActionRelease }
+
+func CapturePolicy(Hold, *models.Order) Action { return ActionCapture }
+
+type Options struct {
+	// Interval between sweeps started by Start
+	Interval time.Duration
+	// MaxAge age of hold after which Policy is applied
+	MaxAge time.Duration
+	// Deadline how long acquirer keeps hold before dropping it, zero disables deadline reporting
+	Deadline time.Duration
+	// WarnBefore holds whose deadline is closer than this are reported as near deadline
+	WarnBefore time.Duration
+	Policy     Policy
+	// Concurrency maximum number of holds processed at once
+	Concurrency int
+	// OnReport receives report of every sweep started by Start
+	OnReport func(report *Report, err error)
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		Interval:    10 * time.Minute,
+		MaxAge:      5 * 24 * time.Hour,
+		Deadline:    7 * 24 * time.Hour,
+		WarnBefore:  24 * time.Hour,
+		Policy:      ReleasePolicy,
+		Concurrency: 4,
+	}
+}
+
+// Result of processing one hold
+type Result struct {
+	Hold     Hold
+	Order    *models.Order
+	Action   Action
+	Deadline time.Time
+	// Resolved hold was finished outside of sweeper (captured, reversed, refunded, declined or expired)
+	Resolved bool
+	Err      error
+}
+
+// Report of one sweep
+type Report struct {
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	Checked      int
+	Released     []Result
+	Captured     []Result
+	Finished     []Result
+	NearDeadline []Result
+	Failed       []Result
+}
+
+func (r *Report) add(result Result) {
+	switch {
+	case result.Err != nil:
+		r.Failed = append(r.Failed, result)
+	case result.Action == ActionRelease:
+		r.Released = append(r.Released, result)
+	case result.Action == ActionCapture:
+		r.Captured = append(r.Captured, result)
+	case !result.Deadline.IsZero():
+		r.NearDeadline = append(r.NearDeadline, result)
+	case result.Resolved:
+		r.Finished = append(r.Finished, result)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stremovskyy/gofondy/consts"
+	"github.com/stremovskyy/gofondy/models"
+)
+
+// Sweeper periodically checks open holds and releases or captures those that got too old
+type Sweeper struct {
+	gateway Gateway
+	source  Source
+	options *Options
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+func New(gateway Gateway, source Source, options *Options) *Sweeper {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	if options.Policy == nil {
+		options.Policy = ReleasePolicy
+	}
+
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+
+	return &Sweeper{gateway: gateway, source: source, options: options}
+}
+
+// Start runs sweeps every Options.Interval in background until Stop is called or ctx is done
+func (s *Sweeper) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return errors.New("sweeper is already started")
+	}
+
+	if s.options.Interval <= 0 {
+		return errors.New("sweeper interval must be positive")
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.stopped = make(chan struct{})
+
+	go func() {
+		defer close(s.stopped)
+
+		ticker := time.NewTicker(s.options.Interval)
+		defer ticker.Stop()
+
+		for {
+			report, err := s.Sweep(ctx)
+			if s.options.OnReport != nil {
+				s.options.OnReport(report, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops scheduling sweeps and waits for running one to finish, holds not started yet are skipped.
+// When ctx is done first its error is returned and sweep keeps finishing in background.
+func (s *Sweeper) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, stopped := s.cancel, s.stopped
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sweep checks all open holds once
+func (s *Sweeper) Sweep(ctx context.Context) (*Report, error) {
+	report := &Report{StartedAt: time.Now()}
+
+	holds, err := s.source.OpenHolds(ctx)
+	if err != nil {
+		return report, fmt.Errorf("cannot load open holds: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	slots := make(chan struct{}, s.options.Concurrency)
+
+	for _, hold := range holds {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+			wg.Add(1)
+
+			go func(hold Hold) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				result := s.process(ctx, hold, report.StartedAt)
+
+				mu.Lock()
+				report.Checked++
+				report.add(result)
+				mu.Unlock()
+			}(hold)
+
+			continue
+		}
+
+		break
+	}
+
+	wg.Wait()
+	report.FinishedAt = time.Now()
+
+	return report, ctx.Err()
+}
+
+func (s *Sweeper) process(ctx context.Context, hold Hold, now time.Time) Result {
+	result := Result{Hold: hold, Action: ActionNone}
+
+	order, err := s.gateway.Status(hold.Request)
+	if err != nil {
+		result.Err = fmt.Errorf("status: %w", err)
+		return result
+	}
+
+	result.Order = order
+
+	if order.ResponseStatus != nil && *order.ResponseStatus != consts.FondyResponseStatusSuccess {
+		result.Err = fmt.Errorf("status: fondy response is %s", *order.ResponseStatus)
+		return result
+	}
+
+	switch order.Lifecycle() {
+	case models.LifecycleHeld:
+	case models.LifecycleCreated, models.LifecycleProcessing:
+		// 3DS or processing is still pending, hold may appear later
+		return result
+	case models.LifecycleUnknown:
+		result.Err = errors.New("status: order state is unknown")
+		return result
+	default:
+		result.Resolved = true
+		result.Err = s.source.Resolve(ctx, hold, order, ActionNone)
+		return result
+	}
+
+	age := now.Sub(hold.CreatedAt)
+
+	if age >= s.options.MaxAge {
+		result.Action = s.options.Policy(hold, order)
+
+		switch result.Action {
+		case ActionRelease:
+			var cancel *models.HoldCancelResult
+			cancel, err = s.gateway.CancelHold(hold.Request)
+			if cancel != nil && cancel.Order != nil {
+				result.Order = cancel.Order
+			}
+		case ActionCapture:
+			var captured *models.Order
+			captured, err = s.gateway.Capture(hold.Request)
+			if captured != nil {
+				result.Order = captured
+			}
+		}
+
+		if err != nil {
+			result.Err = fmt.Errorf("%s: %w", result.Action, err)
+			return result
+		}
+
+		if result.Action != ActionNone {
+			result.Err = s.source.Resolve(ctx, hold, result.Order, result.Action)
+			return result
+		}
+	}
+
+	if s.options.Deadline > 0 {
+		deadline := hold.CreatedAt.Add(s.options.Deadline)
+		if deadline.Sub(now) <= s.options.WarnBefore {
+			result.Deadline = deadline
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package utils
+
+import (
+	"time"
+	// tzdata is embedded, so Kyiv DST rules are known on hosts without zoneinfo
+	_ "time/tzdata"
+)
+
+var kyivLocation = loadKyivLocation()
+
+// KyivLocation returns Europe/Kyiv location used by Fondy for all report dates
+func KyivLocation() *time.Location {
+	return kyivLocation
+}
+
+// loadKyivLocation falls back to the old zone name known to tzdata before 2022b
+func loadKyivLocation() *time.Location {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err == nil {
+		return loc
+	}
+
+	loc, err = time.LoadLocation("Europe/Kiev")
+	if err != nil {
+		panic("utils: embedded tzdata has no Kyiv location: " + err.Error())
+	}
+
+	return loc
+}
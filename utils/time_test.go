@@ -0,0 +1,47 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2026 Anton (stremovskyy) Stremovskyy <stremovskyy@gmail.com>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKyivLocationHasDST(t *testing.T) {
+	tests := []struct {
+		at     time.Time
+		offset int
+	}{
+		{at: time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC), offset: 2 * 60 * 60},
+		{at: time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC), offset: 3 * 60 * 60},
+	}
+
+	for _, tt := range tests {
+		_, offset := tt.at.In(KyivLocation()).Zone()
+		if offset != tt.offset {
+			t.Errorf("offset at %s = %d, want %d", tt.at, offset, tt.offset)
+		}
+	}
+}